@@ -0,0 +1,117 @@
+package lru
+
+// PolicyARC balances recency against frequency with the Adaptive Replacement
+// Cache algorithm by Megiddo and Modha. Entries seen once live in T1 and
+// entries seen at least twice in T2; the keys most recently evicted from
+// either are remembered, without their values, in the ghost lists B1 and B2.
+// A hit in B1 means T1 was too small and grows its target size p, a hit in B2
+// shrinks it again, so the cache adapts to the workload without any tuning.
+var PolicyARC = EvictionPolicy{kind: policyARC}
+
+type arcPolicy[K comparable, V any] struct {
+	capacity *int
+
+	// p is the target size of t1.
+	p int
+
+	t1, t2 *segment[K, V]
+	b1, b2 *ghostList[K]
+
+	// adapted is the incoming node p was already adapted for by victim.
+	adapted *Node[K, V]
+}
+
+func newARCPolicy[K comparable, V any](capacity *int) *arcPolicy[K, V] {
+	return &arcPolicy[K, V]{
+		capacity: capacity,
+		t1:       newSegment[K, V](),
+		t2:       newSegment[K, V](),
+		b1:       newGhostList[K](),
+		b2:       newGhostList[K](),
+	}
+}
+
+func (p *arcPolicy[K, V]) add(node *Node[K, V]) {
+	if p.adapted != node {
+		p.adapt(node.Key)
+	}
+	p.adapted = nil
+
+	// A ghost hit means the key has been seen before.
+	if p.b1.remove(node.Key) || p.b2.remove(node.Key) {
+		p.t2.pushFront(node)
+		return
+	}
+
+	// Keep T1 and B1 within the capacity, and all four lists within twice
+	// the capacity.
+	c := *p.capacity
+	if p.t1.len()+p.b1.len() >= c && p.b1.len() > 0 {
+		p.b1.removeBack()
+	}
+	if p.t1.len()+p.t2.len()+p.b1.len()+p.b2.len() >= 2*c && p.b2.len() > 0 {
+		p.b2.removeBack()
+	}
+	p.t1.pushFront(node)
+}
+
+func (p *arcPolicy[K, V]) access(node *Node[K, V]) bool {
+	if p.t1.remove(node) {
+		p.t2.pushFront(node)
+	} else {
+		p.t2.moveToFront(node)
+	}
+	return true
+}
+
+func (p *arcPolicy[K, V]) remove(node *Node[K, V]) {
+	if !p.t1.remove(node) {
+		p.t2.remove(node)
+	}
+}
+
+// victim implements REPLACE from the paper: it evicts from T1 while T1 is
+// above its target size and from T2 otherwise, remembering the key in the
+// matching ghost list.
+func (p *arcPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	inB2 := false
+	if incoming != nil {
+		inB2 = p.adapt(incoming.Key)
+		p.adapted = incoming
+	}
+
+	c := *p.capacity
+	target := min(p.p, c)
+	t1 := p.t1.len()
+	if t2 := p.t2.len(); t1 > 0 && (t1 > target || (inB2 && t1 == target) || t2 == 0) {
+		node := p.t1.back()
+		p.b1.push(node.Key, c)
+		return node
+	}
+
+	node := p.t2.back()
+	p.b2.push(node.Key, c)
+	return node
+}
+
+// adapt moves the target size of T1 on a ghost hit for key. It reports
+// whether key was found in B2.
+func (p *arcPolicy[K, V]) adapt(key K) bool {
+	switch {
+	case p.b1.contains(key):
+		p.p = min(*p.capacity, p.p+max(p.b2.len()/p.b1.len(), 1))
+	case p.b2.contains(key):
+		p.p = max(0, p.p-max(p.b1.len()/p.b2.len(), 1))
+		return true
+	}
+	return false
+}
+
+func (p *arcPolicy[K, V]) clear() {
+	p.p = 0
+	p.t1.clear()
+	p.t2.clear()
+	p.b1.clear()
+	p.b2.clear()
+	p.adapted = nil
+}
@@ -0,0 +1,103 @@
+package lru
+
+import "testing"
+
+func TestPolicyARC(t *testing.T) {
+	cache := New(4, WithEvictionPolicy[string, int](PolicyARC))
+	policy := cache.policy.(*arcPolicy[string, int])
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Get("Dog")
+
+	if !policy.t2.contains(cache.Hash["Dog"]) || !policy.t1.contains(cache.Hash["Cat"]) {
+		t.Fatalf("Expected Dog in T2 after its second access and Cat in T1")
+	}
+
+	// New entries push the T1 entries out into B1 while p is 0.
+	for _, key := range []string{"Soda", "Tee", "Car"} {
+		cache.Set(key, 0)
+	}
+	if cache.Contains("Cat") || !policy.b1.contains("Cat") {
+		t.Fatalf("Expected Cat to be evicted into B1")
+	}
+	if !cache.Contains("Dog") {
+		t.Errorf("Expected Dog to stay in T2")
+	}
+
+	// A B1 hit grows the target size of T1 and admits the key into T2.
+	cache.Set("Cat", 2)
+	if policy.p != 1 {
+		t.Errorf("Expected p to grow to 1 after a B1 hit, but got: %d", policy.p)
+	}
+	if !policy.t2.contains(cache.Hash["Cat"]) || policy.b1.contains("Cat") {
+		t.Errorf("Expected Cat to move from B1 into T2")
+	}
+}
+
+func TestPolicyARCShrinksTarget(t *testing.T) {
+	cache := New(2, WithEvictionPolicy[string, int](PolicyARC))
+	policy := cache.policy.(*arcPolicy[string, int])
+
+	cache.Set("Dog", 1)
+	cache.Get("Dog")
+	cache.Set("Cat", 2)
+	cache.Get("Cat")
+	policy.p = 2
+
+	// T1 is below its target, so T2 gives up Dog into B2.
+	cache.Set("Soda", 3)
+	if cache.Contains("Dog") || !policy.b2.contains("Dog") {
+		t.Fatalf("Expected Dog to be evicted into B2")
+	}
+
+	// A B2 hit shrinks the target size of T1.
+	cache.Set("Dog", 1)
+	if policy.p != 1 {
+		t.Errorf("Expected p to shrink to 1 after a B2 hit, but got: %d", policy.p)
+	}
+	if !policy.t2.contains(cache.Hash["Dog"]) {
+		t.Errorf("Expected Dog to be admitted into T2")
+	}
+}
+
+func TestPolicyARCScan(t *testing.T) {
+	cache := New(100, WithEvictionPolicy[int, int](PolicyARC))
+
+	for i := 0; i < 20; i++ {
+		cache.Set(-i-1, i)
+		cache.Get(-i - 1)
+	}
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+
+	for i := 0; i < 20; i++ {
+		if !cache.Contains(-i - 1) {
+			t.Fatalf("Expected frequently used entry %d to survive a scan", -i-1)
+		}
+	}
+}
+
+func TestPolicyARCBounds(t *testing.T) {
+	cache := New(10, WithEvictionPolicy[int, int](PolicyARC))
+	policy := cache.policy.(*arcPolicy[int, int])
+
+	for i := 0; i < 5000; i++ {
+		key := (i * 7919) % 37
+		if _, ok := cache.Get(key); !ok {
+			cache.Set(key, i)
+		}
+
+		t1, t2, b1, b2 := policy.t1.len(), policy.t2.len(), policy.b1.len(), policy.b2.len()
+		if t1+t2 != cache.Len() || cache.Len() > 10 {
+			t.Fatalf("Expected T1 and T2 to hold the %d cached entries, but got: %d and %d", cache.Len(), t1, t2)
+		}
+		if t1+b1 > 10 || t1+t2+b1+b2 > 20 {
+			t.Fatalf("Expected ghost lists to stay bounded, but got: T1 %d, T2 %d, B1 %d, B2 %d", t1, t2, b1, b2)
+		}
+		if policy.p < 0 || policy.p > 10 {
+			t.Fatalf("Expected p within [0, 10], but got: %d", policy.p)
+		}
+	}
+}
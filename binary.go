@@ -0,0 +1,160 @@
+package lru
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// binaryMagic starts every MarshalBinary encoding. Its low byte is the
+// version of the format, so a future format can change it.
+const binaryMagic uint16 = 0x4c01
+
+var (
+	// ErrInvalidBinary is returned by UnmarshalBinary for data which is not
+	// an encoding produced by MarshalBinary.
+	ErrInvalidBinary = errors.New("lru: invalid binary cache encoding")
+
+	// ErrUnsupportedVersion is returned by UnmarshalBinary for an encoding
+	// produced by a version of MarshalBinary this package cannot read.
+	ErrUnsupportedVersion = errors.New("lru: unsupported binary cache format version")
+
+	// ErrUnsupportedType is returned by MarshalBinary and UnmarshalBinary for
+	// key or value types they cannot encode.
+	ErrUnsupportedType = errors.New("lru: type does not support binary encoding")
+)
+
+// MarshalBinary encodes the cache in a compact little-endian format: a 2 byte
+// magic number, the capacity and entry count as 8 bytes each, and then every
+// entry, from the least to the most recently used one, as a 4 byte key length,
+// the key, a 4 byte value length and the value. Keys and values must be
+// strings, byte slices, types based on them or implement
+// encoding.BinaryMarshaler. Expiration
+// times, costs and statistics are not included.
+func (c *Cache[K, V]) MarshalBinary() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	data := binary.LittleEndian.AppendUint16(nil, binaryMagic)
+	data = binary.LittleEndian.AppendUint64(data, uint64(c.capacity))
+	data = binary.LittleEndian.AppendUint64(data, uint64(c.LinkedList.Length))
+
+	for node := c.LinkedList.Tail.Left; node != c.LinkedList.Head; node = node.Left {
+		for _, field := range []any{node.Key, node.Value} {
+			b, err := marshalField(field)
+			if err != nil {
+				return nil, err
+			}
+			if len(b) > math.MaxUint32 {
+				return nil, fmt.Errorf("lru: field of %d bytes is too large to encode", len(b))
+			}
+
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(b)))
+			data = append(data, b...)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the cache with the entries encoded
+// by MarshalBinary, keeping their recently used order, and takes over the
+// encoded capacity. Like UnmarshalJSON it can be used on a zero Cache.
+func (c *Cache[K, V]) UnmarshalBinary(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(data) >= 2 {
+		magic := binary.LittleEndian.Uint16(data)
+		if magic>>8 == binaryMagic>>8 && magic != binaryMagic {
+			return fmt.Errorf("%w %d, expected %d", ErrUnsupportedVersion, magic&0xff, binaryMagic&0xff)
+		}
+	}
+	if len(data) < 18 || binary.LittleEndian.Uint16(data) != binaryMagic {
+		return ErrInvalidBinary
+	}
+
+	capacity := binary.LittleEndian.Uint64(data[2:])
+	count := binary.LittleEndian.Uint64(data[10:])
+	data = data[18:]
+	if capacity > math.MaxInt || count > uint64(len(data))/8 {
+		return ErrInvalidBinary
+	}
+
+	entries := make([]Entry[K, V], count)
+	for i := range entries {
+		var key, value []byte
+		var ok bool
+		if key, data, ok = readField(data); !ok {
+			return ErrInvalidBinary
+		}
+		if value, data, ok = readField(data); !ok {
+			return ErrInvalidBinary
+		}
+
+		if err := unmarshalField(key, &entries[i].Key); err != nil {
+			return err
+		}
+		if err := unmarshalField(value, &entries[i].Value); err != nil {
+			return err
+		}
+	}
+	if len(data) != 0 {
+		return ErrInvalidBinary
+	}
+
+	return c.restore(int(capacity), entries)
+}
+
+// marshalField encodes a key or value. Types which implement
+// encoding.BinaryMarshaler use it; other types are accepted by kind, so named
+// string and byte slice types encode like string and []byte.
+func marshalField(v any) ([]byte, error) {
+	if m, ok := v.(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.String:
+		return []byte(rv.String()), nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return rv.Bytes(), nil
+	}
+	return nil, ErrUnsupportedType
+}
+
+// unmarshalField decodes b into the key or value ptr points to, the inverse
+// of marshalField.
+func unmarshalField(b []byte, ptr any) error {
+	if u, ok := ptr.(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(b)
+	}
+
+	rv := reflect.ValueOf(ptr).Elem()
+	switch {
+	case rv.Kind() == reflect.String:
+		rv.SetString(string(b))
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		rv.SetBytes(append([]byte(nil), b...))
+	default:
+		return ErrUnsupportedType
+	}
+	return nil
+}
+
+// readField splits a length-prefixed field off data.
+func readField(data []byte) (field, rest []byte, ok bool) {
+	if len(data) < 4 {
+		return nil, nil, false
+	}
+
+	n := binary.LittleEndian.Uint32(data)
+	data = data[4:]
+	if uint64(n) > uint64(len(data)) {
+		return nil, nil, false
+	}
+	return data[:n], data[n:], true
+}
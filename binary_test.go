@@ -0,0 +1,136 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+	"testing/quick"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	cache := New[string, string](3)
+	cache.Set("Dog", "Woof")
+	cache.Set("Cat", "Meow")
+	cache.Set("Soda", "")
+	cache.Get("Dog")
+
+	data, err := cache.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored Cache[string, string]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCacheState := []string{"Dog", "Soda", "Cat"}
+	actualCacheState := getCacheState(&restored)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if value, _ := restored.Get("Cat"); value != "Meow" {
+		t.Errorf("Expected Meow, but got: %q", value)
+	}
+}
+
+func TestBinaryRoundTripQuick(t *testing.T) {
+	roundTrip := func(keys []string, values [][]byte, capacity uint8) bool {
+		cache := New[string, []byte](int(capacity) + 1)
+		for i, key := range keys {
+			if i < len(values) {
+				cache.Set(key, values[i])
+			}
+		}
+
+		data, err := cache.MarshalBinary()
+		if err != nil {
+			return false
+		}
+
+		var restored Cache[string, []byte]
+		if err := restored.UnmarshalBinary(data); err != nil {
+			return false
+		}
+		if restored.Cap() != cache.Cap() || !equalSlice(restored.Keys(), cache.Keys()) {
+			return false
+		}
+		for _, key := range cache.Keys() {
+			want, _ := cache.Peek(key)
+			got, _ := restored.Peek(key)
+			if string(want) != string(got) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	cache := New[string, string](3)
+	cache.Set("Dog", "Woof")
+	data, err := cache.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := func(data []byte) bool {
+		var restored Cache[string, string]
+		return errors.Is(restored.UnmarshalBinary(data), ErrInvalidBinary)
+	}
+
+	badMagic := append([]byte{0xff, 0xff}, data[2:]...)
+	if !corrupt(badMagic) {
+		t.Errorf("Expected ErrInvalidBinary for a wrong magic number")
+	}
+	for n := 0; n < len(data); n++ {
+		if !corrupt(data[:n]) {
+			t.Errorf("Expected ErrInvalidBinary for data truncated to %d bytes", n)
+		}
+	}
+	if !corrupt(append(data, 0)) {
+		t.Errorf("Expected ErrInvalidBinary for trailing bytes")
+	}
+
+	if quick.Check(func(data []byte) bool {
+		var restored Cache[string, string]
+		return restored.UnmarshalBinary(data) != nil || restored.Len() >= 0
+	}, nil) != nil {
+		t.Errorf("Expected arbitrary data not to panic")
+	}
+}
+
+type animal string
+
+type sound []byte
+
+func TestBinaryRoundTripNamedTypes(t *testing.T) {
+	cache := New[animal, sound](2)
+	cache.Set("Dog", sound("Woof"))
+	cache.Set("Cat", sound("Meow"))
+
+	data, err := cache.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored Cache[animal, sound]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := restored.Get("Dog"); string(value) != "Woof" {
+		t.Errorf("Expected Woof, but got: %q", value)
+	}
+}
+
+func TestMarshalBinaryUnsupportedType(t *testing.T) {
+	cache := New[int, string](3)
+	cache.Set(1, "Dog")
+
+	if _, err := cache.MarshalBinary(); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, but got: %v", err)
+	}
+}
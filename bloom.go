@@ -0,0 +1,121 @@
+package lru
+
+import "math"
+
+// bloomFilter is a counting Bloom filter over the cached keys. Every key sets
+// k of its counters, derived from hashKey by double hashing; a key whose
+// counters are not all set was never added. Counters are decremented again
+// when entries are removed, so the filter follows deletions without being
+// rebuilt. A counter which overflows stays saturated, which can only cause
+// false positives.
+type bloomFilter struct {
+	counters []uint8
+	mask     uint64
+	hashes   int
+
+	// expectedItems and falsePositiveRate are the parameters the filter was
+	// sized for, kept to create empty copies.
+	expectedItems     int
+	falsePositiveRate float64
+}
+
+// WithBloomFilter makes Get, Fetch and the other lookups consult a counting
+// Bloom filter before the hash map, so keys which were never cached are
+// reported as misses without a map lookup. The filter is sized to hold
+// expectedItems keys with the given false positive rate; a rate outside (0, 1)
+// selects 1%. There are no false negatives, so hits are unaffected, but each
+// insertion and removal updates the filter too. Go maps already answer misses
+// quickly, so the filter does not necessarily make misses faster; see
+// BenchmarkGetMiss.
+func WithBloomFilter[K comparable, V any](expectedItems int, falsePositiveRate float64) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.bloom = newBloomFilter(expectedItems, falsePositiveRate)
+	}
+}
+
+func newBloomFilter(expectedItems int, falsePositiveRate float64) *bloomFilter {
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+	expectedItems = max(expectedItems, 1)
+
+	// The optimal number of counters and hash functions for n items and a
+	// false positive rate p are -n*ln(p)/ln(2)^2 and m/n*ln(2).
+	// The counters are rounded up to a power of two so indexes can be
+	// masked, which only lowers the false positive rate.
+	m := math.Ceil(-float64(expectedItems) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(expectedItems) * math.Ln2)
+	counters := 64
+	for counters < int(m) {
+		counters *= 2
+	}
+
+	return &bloomFilter{
+		counters:          make([]uint8, counters),
+		mask:              uint64(counters - 1),
+		hashes:            max(int(k), 1),
+		expectedItems:     expectedItems,
+		falsePositiveRate: falsePositiveRate,
+	}
+}
+
+// empty returns a filter with the same size as b and no keys, or nil for a
+// nil b.
+func (b *bloomFilter) empty() *bloomFilter {
+	if b == nil {
+		return nil
+	}
+	return newBloomFilter(b.expectedItems, b.falsePositiveRate)
+}
+
+func (b *bloomFilter) add(h uint64) {
+	step := bloomStep(h)
+	for i := 0; i < b.hashes; i++ {
+		j := b.index(h, step, i)
+		if b.counters[j] < math.MaxUint8 {
+			b.counters[j]++
+		}
+	}
+}
+
+func (b *bloomFilter) remove(h uint64) {
+	step := bloomStep(h)
+	for i := 0; i < b.hashes; i++ {
+		j := b.index(h, step, i)
+		if b.counters[j] > 0 && b.counters[j] < math.MaxUint8 {
+			b.counters[j]--
+		}
+	}
+}
+
+// mayContain reports whether the key hashing to h may have been added. False
+// is definite.
+func (b *bloomFilter) mayContain(h uint64) bool {
+	step := bloomStep(h)
+	for i := 0; i < b.hashes; i++ {
+		if b.counters[b.index(h, step, i)] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *bloomFilter) reset() {
+	clear(b.counters)
+}
+
+// index returns the counter used by the i-th hash function for the key
+// hashing to h, combining h and step by double hashing.
+func (b *bloomFilter) index(h, step uint64, i int) int {
+	return int((h + uint64(i)*step) & b.mask)
+}
+
+// bloomStep derives the second hash used for double hashing from h. It mixes
+// h so indexes are spread even when keys differ in few bits, and is odd so it
+// is never 0.
+func bloomStep(h uint64) uint64 {
+	h ^= h >> 31
+	h *= 0x9e3779b97f4a7c15
+	h ^= h >> 29
+	return h | 1
+}
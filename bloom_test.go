@@ -0,0 +1,56 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	cache := New(100, WithBloomFilter[string, int](100, 0.01))
+
+	for i := 0; i < 100; i++ {
+		cache.Set(fmt.Sprintf("Element%d", i), i)
+	}
+	for i := 0; i < 100; i++ {
+		if value, found := cache.Get(fmt.Sprintf("Element%d", i)); !found || value != i {
+			t.Fatalf("Expected (%d, true), but got: (%d, %t)", i, value, found)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if cache.bloom.mayContain(hashKey(fmt.Sprintf("Missing%d", i))) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 300 {
+		t.Errorf("Expected a false positive rate around 1%%, but got %d in 10000", falsePositives)
+	}
+
+	// Removed keys leave the filter, whether deleted, evicted or cleared.
+	cache.Delete("Element0")
+	cache.Set("Element100", 100)
+	cache.Set("Element101", 101)
+	for _, key := range []string{"Element0", "Element1"} {
+		if cache.bloom.mayContain(hashKey(key)) {
+			t.Errorf("Expected %s to be removed from the filter", key)
+		}
+	}
+	if _, err := cache.Fetch("Element0"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, but got: %v", err)
+	}
+
+	cache.Clear()
+	if cache.bloom.mayContain(hashKey("Element50")) {
+		t.Errorf("Expected Clear to empty the filter")
+	}
+	cache.Set("Element50", 50)
+	if value, found := cache.Get("Element50"); !found || value != 50 {
+		t.Errorf("Expected (50, true) after Clear, but got: (%d, %t)", value, found)
+	}
+
+	clone := cache.Clone()
+	if clone.bloom == cache.bloom || !clone.bloom.mayContain(hashKey("Element50")) {
+		t.Errorf("Expected the clone to have its own filter holding its keys")
+	}
+}
@@ -0,0 +1,103 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned instead of calling the loader while the circuit
+// breaker set with WithCircuitBreaker is open.
+var ErrCircuitOpen = errors.New("lru: loader circuit breaker is open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker counts consecutive loader failures and stops calling the loader
+// once there are too many, until resetTimeout has passed. It has its own
+// lock since a Cache calls the loader without holding its lock.
+type breaker struct {
+	threshold    int
+	resetTimeout time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// WithCircuitBreaker stops calling the loader after threshold consecutive
+// failures. While the circuit is open, misses fail right away with
+// ErrCircuitOpen. Once resetTimeout has passed a single call is let through:
+// if it succeeds the circuit closes again, otherwise it stays open for another
+// resetTimeout. Background revalidations go through the breaker too.
+func WithCircuitBreaker[K comparable, V any](threshold int, resetTimeout time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.breaker = &breaker{threshold: max(threshold, 1), resetTimeout: resetTimeout}
+	}
+}
+
+// allow reports whether the loader may be called at now, moving an open
+// circuit to half-open once its timeout has passed.
+func (b *breaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if now.Sub(b.openedAt) < b.resetTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = breakerHalfOpen
+		return nil
+	case breakerHalfOpen:
+		// Only the trial call goes through.
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of a loader call made at now.
+func (b *breaker) record(err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state, b.failures = breakerClosed, 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state, b.openedAt = breakerOpen, now
+	}
+}
+
+func (b *breaker) clone() *breaker {
+	if b == nil {
+		return nil
+	}
+	return &breaker{threshold: b.threshold, resetTimeout: b.resetTimeout}
+}
+
+// callLoader calls the configured loader through the circuit breaker, if any.
+func (c *Cache[K, V]) callLoader(ctx context.Context, key K) (V, error) {
+	if c.breaker == nil {
+		return c.loader(ctx, key)
+	}
+
+	if err := c.breaker.allow(c.now()); err != nil {
+		var zero V
+		return zero, err
+	}
+	value, err := c.loader(ctx, key)
+	c.breaker.record(err, c.now())
+	return value, err
+}
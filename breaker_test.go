@@ -0,0 +1,61 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	errStoreDown := errors.New("store is down")
+	calls := 0
+	down := true
+
+	cache := New(4,
+		WithLoader(func(key string) (int, error) {
+			calls++
+			if down {
+				return 0, errStoreDown
+			}
+			return len(key), nil
+		}),
+		WithCircuitBreaker[string, int](3, time.Second),
+	)
+	clock := newTestClock(cache)
+
+	for i := 0; i < 3; i++ {
+		if _, err := cache.Fetch("Dog"); !errors.Is(err, errStoreDown) {
+			t.Fatalf("Expected the loader error, but got: %v", err)
+		}
+	}
+
+	// The circuit is open, so the loader is no longer called.
+	if _, err := cache.Fetch("Dog"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, but got: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 loader calls, but got: %d", calls)
+	}
+
+	// A failed trial after the timeout opens the circuit again.
+	clock.Advance(time.Second)
+	if _, err := cache.Fetch("Dog"); !errors.Is(err, errStoreDown) {
+		t.Errorf("Expected the trial call to reach the loader, but got: %v", err)
+	}
+	if _, err := cache.Fetch("Dog"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen after a failed trial, but got: %v", err)
+	}
+
+	// A successful trial closes it.
+	down = false
+	clock.Advance(time.Second)
+	if value, err := cache.Fetch("Dog"); err != nil || value != 3 {
+		t.Errorf("Expected (3, nil), but got: (%d, %v)", value, err)
+	}
+	if value, err := cache.Fetch("Cat"); err != nil || value != 3 {
+		t.Errorf("Expected the closed circuit to call the loader, but got: (%d, %v)", value, err)
+	}
+	if calls != 6 {
+		t.Errorf("Expected 6 loader calls, but got: %d", calls)
+	}
+}
@@ -0,0 +1,30 @@
+package lru
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ErrEntryTooLarge is returned when a single entry is larger than the limit
+// set with WithMaxBytes. It matches ErrCacheFull.
+var ErrEntryTooLarge = fmt.Errorf("%w: entry exceeds the cache byte limit", ErrCacheFull)
+
+// sizeOf estimates the memory taken by an entry, or returns 0 when the cache
+// has no byte limit. The node holds the key and value themselves, so only the
+// contents of strings and byte slices are added to it.
+func (c *Cache[K, V]) sizeOf(key K, value V) int64 {
+	if c.stats.maxBytes <= 0 {
+		return 0
+	}
+	return int64(unsafe.Sizeof(Node[K, V]{})) + contentSize(key) + contentSize(value)
+}
+
+func contentSize(v any) int64 {
+	switch v := v.(type) {
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package lru
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestWithMaxBytes(t *testing.T) {
+	overhead := int64(unsafe.Sizeof(Node[string, string]{}))
+	cache := New(100, WithMaxBytes[string, string](3*overhead+30))
+
+	// Every entry is a 3 byte key and a 7 byte value.
+	cache.Set("Dog", "Woof...")
+	cache.Set("Cat", "Meow...")
+	cache.Set("Car", "Vroom..")
+
+	stats := cache.Stats()
+	if stats.CurrentBytes != 3*overhead+30 || stats.MaxBytes != 3*overhead+30 {
+		t.Errorf("Expected %d of %d bytes, but got: %d of %d", 3*overhead+30, 3*overhead+30, stats.CurrentBytes, stats.MaxBytes)
+	}
+
+	cache.Set("Tee", "Leaf...")
+	expectedCacheState := []string{"Tee", "Car", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	// A larger value pushes out more entries.
+	cache.Set("Car", strings.Repeat("x", 17))
+	expectedCacheState = []string{"Car", "Tee"}
+	actualCacheState = getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if stats := cache.Stats(); stats.CurrentBytes != 2*overhead+30 {
+		t.Errorf("Expected %d bytes, but got: %d", 2*overhead+30, stats.CurrentBytes)
+	}
+
+	cache.Delete("Car")
+	cache.ResetStats()
+	if stats := cache.Stats(); stats.CurrentBytes != overhead+10 {
+		t.Errorf("Expected %d bytes after Delete and ResetStats, but got: %d", overhead+10, stats.CurrentBytes)
+	}
+
+	cache.Clear()
+	if stats := cache.Stats(); stats.CurrentBytes != 0 {
+		t.Errorf("Expected 0 bytes after Clear, but got: %d", stats.CurrentBytes)
+	}
+}
+
+func TestWithMaxBytesTooLarge(t *testing.T) {
+	cache := New(100, WithMaxBytes[string, []byte](1024))
+
+	if err := cache.Set("Dog", make([]byte, 2048)); !errors.Is(err, ErrEntryTooLarge) {
+		t.Errorf("Expected ErrEntryTooLarge, but got: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected the entry not to be cached")
+	}
+}
+
+func TestWithoutMaxBytes(t *testing.T) {
+	cache := New[string, string](3)
+	cache.Set("Dog", "Woof")
+
+	if stats := cache.Stats(); stats.CurrentBytes != 0 || stats.MaxBytes != 0 {
+		t.Errorf("Expected no byte accounting without a limit, but got: %d of %d", stats.CurrentBytes, stats.MaxBytes)
+	}
+}
+
+func TestShardedMaxBytes(t *testing.T) {
+	cache, err := NewSharded(64, 4, WithMaxBytes[string, string](4096))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats := cache.Stats(); stats.MaxBytes != 4096 {
+		t.Errorf("Expected the byte limit to be split over the shards, but got: %d", stats.MaxBytes)
+	}
+}
@@ -0,0 +1,208 @@
+// Package cachetest provides test doubles for code that depends on
+// lru.Interface.
+package cachetest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+// Call is a single recorded method invocation on a Mock.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Mock is an in-memory lru.Interface that never evicts entries and records
+// every call made to it. Values can be injected up front with Stub, or with
+// ExpectGet and ExpectMiss on a mock created by NewStrictMock.
+type Mock[K comparable, V any] struct {
+	mu       sync.Mutex
+	values   map[K]V
+	capacity int
+	calls    []Call
+
+	// t is set for strict mocks, which only allow reading keys listed in
+	// expected.
+	t        testing.TB
+	expected map[K]bool
+}
+
+var _ lru.Interface[string, string] = (*Mock[string, string])(nil)
+
+// NewMock creates an empty Mock.
+func NewMock[K comparable, V any]() *Mock[K, V] {
+	return &Mock[K, V]{values: map[K]V{}, expected: map[K]bool{}}
+}
+
+// NewStrictMock creates an empty Mock which fails t and stops the test when
+// Get, Peek or Contains is called with a key that was not set up with
+// ExpectGet or ExpectMiss. Like t.Fatalf, this only works when the mock is
+// called from the goroutine running the test.
+func NewStrictMock[K comparable, V any](t testing.TB) *Mock[K, V] {
+	m := NewMock[K, V]()
+	m.t = t
+	return m
+}
+
+// ExpectGet makes reads of key find value.
+func (m *Mock[K, V]) ExpectGet(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[key] = value
+	m.expected[key] = true
+}
+
+// ExpectMiss makes reads of key report a miss.
+func (m *Mock[K, V]) ExpectMiss(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.values, key)
+	m.expected[key] = true
+}
+
+// AssertCalled fails t unless method was called with args, compared with
+// reflect.DeepEqual. It reports whether such a call was found.
+func (m *Mock[K, V]) AssertCalled(t testing.TB, method string, args ...any) bool {
+	t.Helper()
+
+	for _, call := range m.Calls() {
+		if call.Method == method && reflect.DeepEqual(call.Args, args) {
+			return true
+		}
+	}
+	t.Errorf("cachetest: expected a call to %s%v, but got: %v", method, args, m.Calls())
+	return false
+}
+
+// Stub stores value under key without recording a call.
+func (m *Mock[K, V]) Stub(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[key] = value
+}
+
+// SetCap sets the value returned by Cap.
+func (m *Mock[K, V]) SetCap(capacity int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.capacity = capacity
+}
+
+// Calls returns a copy of the calls recorded so far, in invocation order.
+func (m *Mock[K, V]) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+func (m *Mock[K, V]) record(method string, args ...any) {
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// checkExpected fails and stops the test of a strict mock when key was not
+// expected to be read.
+func (m *Mock[K, V]) checkExpected(method string, key K) {
+	if m.t != nil && !m.expected[key] {
+		m.t.Helper()
+		m.t.Fatalf("cachetest: unexpected call to %s(%v)", method, key)
+	}
+}
+
+func (m *Mock[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Get", key)
+	m.checkExpected("Get", key)
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *Mock[K, V]) Set(key K, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Set", key, value)
+	m.values[key] = value
+	return nil
+}
+
+func (m *Mock[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Delete", key)
+	_, ok := m.values[key]
+	delete(m.values, key)
+	return ok
+}
+
+func (m *Mock[K, V]) Peek(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Peek", key)
+	m.checkExpected("Peek", key)
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *Mock[K, V]) Contains(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Contains", key)
+	m.checkExpected("Contains", key)
+	_, ok := m.values[key]
+	return ok
+}
+
+func (m *Mock[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Len")
+	return len(m.values)
+}
+
+func (m *Mock[K, V]) Cap() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Cap")
+	return m.capacity
+}
+
+func (m *Mock[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record("Clear")
+	m.values = map[K]V{}
+}
+
+// ForEach calls fn for every stored entry in unspecified order.
+func (m *Mock[K, V]) ForEach(fn func(key K, value V) bool) {
+	m.mu.Lock()
+	m.record("ForEach")
+	entries := make(map[K]V, len(m.values))
+	for k, v := range m.values {
+		entries[k] = v
+	}
+	m.mu.Unlock()
+
+	for k, v := range entries {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
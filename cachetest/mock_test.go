@@ -0,0 +1,101 @@
+package cachetest
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+func lookup(c lru.Interface[string, int], key string) int {
+	value, _ := c.Get(key)
+	return value
+}
+
+func TestMock(t *testing.T) {
+	mock := NewMock[string, int]()
+	mock.Stub("Dog", 42)
+
+	if value := lookup(mock, "Dog"); value != 42 {
+		t.Errorf("Expected stubbed value 42, but got: %d", value)
+	}
+	if _, found := mock.Get("Cat"); found {
+		t.Errorf("Expected miss for key not stubbed")
+	}
+
+	mock.Set("Cat", 1)
+	if !mock.Delete("Cat") {
+		t.Errorf("Expected Delete to report removal of stored key")
+	}
+
+	calls := mock.Calls()
+	expectedMethods := []string{"Get", "Get", "Set", "Delete"}
+	if len(calls) != len(expectedMethods) {
+		t.Fatalf("Expected %d calls, but got: %v", len(expectedMethods), calls)
+	}
+	for i, method := range expectedMethods {
+		if calls[i].Method != method {
+			t.Errorf("Expected call %d to be %s, but got: %s", i, method, calls[i].Method)
+		}
+	}
+	if calls[2].Args[0] != "Cat" || calls[2].Args[1] != 1 {
+		t.Errorf("Expected Set to record (Cat, 1), but got: %v", calls[2].Args)
+	}
+}
+
+// recorder captures the failures a strict mock reports.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// Fatalf records the failure and exits the calling goroutine, like the
+// Fatalf of a real test.
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func TestStrictMock(t *testing.T) {
+	r := &recorder{TB: t}
+	mock := NewStrictMock[string, int](r)
+	mock.ExpectGet("Dog", 42)
+	mock.ExpectMiss("Cat")
+
+	if value, found := mock.Get("Dog"); !found || value != 42 {
+		t.Errorf("Expected (42, true), but got: (%d, %t)", value, found)
+	}
+	if _, found := mock.Get("Cat"); found {
+		t.Errorf("Expected a miss for Cat")
+	}
+	if len(r.errors) != 0 {
+		t.Errorf("Expected no failures for expected keys, but got: %v", r.errors)
+	}
+
+	// An unexpected key stops the test, so the mock is called from a
+	// goroutine standing in for it.
+	stopped := true
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mock.Peek("Soda")
+		stopped = false
+	}()
+	<-done
+	if !stopped || len(r.errors) != 1 {
+		t.Errorf("Expected an unexpected key to fail and stop the test, but got stopped %t and: %v", stopped, r.errors)
+	}
+
+	mock.AssertCalled(t, "Get", "Dog")
+	mock.AssertCalled(r, "Get", "Tee")
+	if len(r.errors) != 2 {
+		t.Errorf("Expected AssertCalled to fail for a missing call, but got: %v", r.errors)
+	}
+}
@@ -0,0 +1,92 @@
+package lru
+
+import "log/slog"
+
+// Operations reported by ChangeEvent.Op.
+const (
+	ChangeSet    = "set"
+	ChangeDelete = "delete"
+	ChangeEvict  = "evict"
+)
+
+// DefaultChangeBuffer is the number of events the channel returned by Changes
+// holds, unless set with WithChangeBuffer.
+const DefaultChangeBuffer = 256
+
+// ChangeEvent describes a single change to the cache, see Changes. OldValue
+// is the zero value for a key which was not cached, and NewValue for an entry
+// which was deleted or evicted.
+type ChangeEvent[K comparable, V any] struct {
+	Op       string
+	Key      K
+	OldValue V
+	NewValue V
+}
+
+// WithChangeBuffer sets the number of events the channel returned by Changes
+// holds.
+func WithChangeBuffer[K comparable, V any](n int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.changeBuffer = n
+	}
+}
+
+// Changes returns a channel receiving an event for every change made from now
+// on: a ChangeSet for every value stored, including values filled by a loader
+// and by Check, a ChangeDelete for every entry deleted or cleared, and a
+// ChangeEvict for every entry evicted to make room or because it expired.
+// Every call returns the same channel. Changes are never held up by a slow
+// consumer: when the channel is full the event is dropped and a warning is
+// logged to the logger set with WithLogger.
+func (c *Cache[K, V]) Changes() <-chan ChangeEvent[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.changes == nil {
+		c.changes = make(chan ChangeEvent[K, V], c.changeBufferSize())
+	}
+	return c.changes
+}
+
+func (c *Cache[K, V]) changeBufferSize() int {
+	if c.changeBuffer <= 0 {
+		return DefaultChangeBuffer
+	}
+	return c.changeBuffer
+}
+
+// notifyChange sends an event to the channel returned by Changes, if any, and
+// the new value to the watchers of key.
+func (c *Cache[K, V]) notifyChange(op string, key K, oldValue, newValue V) {
+	c.notifyWatchers(key, newValue)
+	if c.changes == nil {
+		return
+	}
+
+	select {
+	case c.changes <- ChangeEvent[K, V]{Op: op, Key: key, OldValue: oldValue, NewValue: newValue}:
+	default:
+		c.log(slog.LevelWarn, "lru: change event dropped, channel is full", "op", op, "key", key)
+	}
+}
+
+// Changes behaves like Cache.Changes.
+func (s *SyncCache[K, V]) Changes() <-chan ChangeEvent[K, V] {
+	return s.cache.Changes()
+}
+
+// Changes behaves like Cache.Changes, with the changes of all shards sent to
+// a single channel. Changes to different shards may arrive slightly out of
+// order.
+func (c *ShardedCache[K, V]) Changes() <-chan ChangeEvent[K, V] {
+	c.changesOnce.Do(func() {
+		changes := make(chan ChangeEvent[K, V], c.shards[0].cache.changeBufferSize())
+		for _, s := range c.shards {
+			s.cache.mu.Lock()
+			s.cache.changes = changes
+			s.cache.mu.Unlock()
+		}
+		c.changes = changes
+	})
+	return c.changes
+}
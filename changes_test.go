@@ -0,0 +1,100 @@
+package lru
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func receiveChanges[K comparable, V any](changes <-chan ChangeEvent[K, V]) []ChangeEvent[K, V] {
+	var events []ChangeEvent[K, V]
+	for {
+		select {
+		case event := <-changes:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestChanges(t *testing.T) {
+	cache := New[string, int](2)
+	clock := newTestClock(cache)
+	changes := cache.Changes()
+
+	cache.Set("Dog", 1)
+	cache.Set("Dog", 2)
+	cache.Swap("Dog", 3)
+	cache.SetWithTTL("Cat", 4, time.Second)
+	cache.Set("Soda", 5)
+	clock.Advance(2 * time.Second)
+	cache.Get("Cat")
+	cache.Delete("Soda")
+	cache.Set("Tee", 6)
+	cache.Clear()
+
+	expected := []ChangeEvent[string, int]{
+		{Op: ChangeSet, Key: "Dog", NewValue: 1},
+		{Op: ChangeSet, Key: "Dog", OldValue: 1, NewValue: 2},
+		{Op: ChangeSet, Key: "Dog", OldValue: 2, NewValue: 3},
+		{Op: ChangeSet, Key: "Cat", NewValue: 4},
+		{Op: ChangeEvict, Key: "Dog", OldValue: 3},
+		{Op: ChangeSet, Key: "Soda", NewValue: 5},
+		{Op: ChangeEvict, Key: "Cat", OldValue: 4},
+		{Op: ChangeDelete, Key: "Soda", OldValue: 5},
+		{Op: ChangeSet, Key: "Tee", NewValue: 6},
+		{Op: ChangeDelete, Key: "Tee", OldValue: 6},
+	}
+	if events := receiveChanges(changes); !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected events %v, but got: %v", expected, events)
+	}
+	if cache.Changes() != changes {
+		t.Error("Expected Changes to return the same channel")
+	}
+}
+
+func TestChangesDropped(t *testing.T) {
+	var buf bytes.Buffer
+	cache := New(10, WithChangeBuffer[string, int](2), WithLogger[string, int](newTestLogger(&buf)))
+	changes := cache.Changes()
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+
+	if events := receiveChanges(changes); len(events) != 2 || events[1].Key != "Cat" {
+		t.Errorf("Expected the events for Dog and Cat, but got: %v", events)
+	}
+	expected := `level=WARN msg="lru: change event dropped, channel is full" op=set key=Soda`
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected a warning %q, but got: %q", expected, buf.String())
+	}
+}
+
+func TestShardedChanges(t *testing.T) {
+	cache, err := NewSharded[int, int](400, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	changes := cache.Changes()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				cache.Set(i*10+j, j)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if events := receiveChanges(changes); len(events) != 40 {
+		t.Errorf("Expected 40 events from all shards, but got: %d", len(events))
+	}
+}
@@ -0,0 +1,51 @@
+package lru
+
+// Clone returns a deep copy of the cache: a new cache with the same options
+// and capacity, holding copies of the entries with the same expiration times
+// in the same recently used order. Changes to either cache do not affect the
+// other, though values which are pointers or contain them are shared. The
+// eviction policy of the clone starts over from the copied entries and its
+// statistics start at zero.
+func (c *Cache[K, V]) Clone() *Cache[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	clone := &Cache[K, V]{
+		LinkedList:     createLinkedList[K, V](),
+		Hash:           createHash[K, V](c.capacity),
+		capacity:       c.capacity,
+		evictionPolicy: c.evictionPolicy,
+		now:            c.now,
+		defaultTTL:     c.defaultTTL,
+		slidingTTL:     c.slidingTTL,
+		onEvict:        c.onEvict,
+		onFlush:        c.onFlush,
+		onHit:          c.onHit,
+		onMiss:         c.onMiss,
+		expired:        c.expired,
+		changeBuffer:   c.changeBuffer,
+		logger:         c.logger,
+		loader:         c.loader,
+		writer:         c.writer,
+		limiter:        cloneLimiter(c.limiter),
+		breaker:        c.breaker.clone(),
+		bloom:          c.bloom.empty(),
+		ghost:          c.ghost.clone(),
+	}
+	clone.stats.maxBytes = c.stats.maxBytes
+	clone.policy = newPolicy(clone.evictionPolicy, clone)
+
+	for node := c.LinkedList.Tail.Left; node != c.LinkedList.Head; node = node.Left {
+		copied := clone.newNode()
+		copied.copyFrom(node)
+		copied.cost = node.cost
+		copied.CreatedAt, copied.LastAccessedAt = node.CreatedAt, node.LastAccessedAt
+		copied.AccessCount = node.AccessCount
+		copied.negative = node.negative
+
+		clone.Hash[copied.Key] = copied
+		clone.add(copied)
+		clone.tag(copied, node.tags)
+	}
+	return clone
+}
@@ -0,0 +1,77 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	cache.SetWithTTL("Cat", 2, time.Second)
+	cache.Set("Soda", 3)
+	cache.Get("Dog")
+
+	clone := cache.Clone()
+	if !equalSlice(cache.Keys(), clone.Keys()) || !equalSlice(cache.Values(), clone.Values()) {
+		t.Fatalf("Expected clone entries: %v, but got: %v", cache.Entries(), clone.Entries())
+	}
+
+	// Mutations stay on their own side.
+	clone.Set("Dog", 10)
+	clone.Set("Tee", 4)
+	cache.Delete("Soda")
+
+	if value, _ := cache.Peek("Dog"); value != 1 {
+		t.Errorf("Expected the original to keep Dog at 1, but got: %d", value)
+	}
+	if cache.Contains("Tee") {
+		t.Errorf("Expected the original not to see entries added to the clone")
+	}
+	if !clone.Contains("Soda") {
+		t.Errorf("Expected the clone to keep entries deleted from the original")
+	}
+
+	expectedCacheState := []string{"Tee", "Dog", "Soda"}
+	actualCacheState := getCacheState(clone)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected clone state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	// The clone shares the clock and keeps the expiration times.
+	clock.Advance(2 * time.Second)
+	other := cache.Clone()
+	if other.Contains("Cat") {
+		t.Errorf("Expected the cloned TTL entry to expire")
+	}
+	if remaining, ok := clone.TTL("Cat"); ok {
+		t.Errorf("Expected Cat to have expired in the clone, but %s remain", remaining)
+	}
+}
+
+func TestCloneKeepsOptions(t *testing.T) {
+	var evicted []string
+	cache := New(2,
+		WithEvictionPolicy[string, int](PolicyFIFO),
+		WithOnEvict(func(key string, value int) {
+			evicted = append(evicted, key)
+		}))
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+
+	clone := cache.Clone()
+	clone.Get("Dog")
+	clone.Set("Soda", 3)
+
+	if clone.Contains("Dog") {
+		t.Errorf("Expected the clone to keep evicting in FIFO order")
+	}
+	if !equalSlice([]string{"Dog"}, evicted) {
+		t.Errorf("Expected the clone to call the eviction callback, but got: %v", evicted)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected the original to be unaffected, but got Len: %d", cache.Len())
+	}
+}
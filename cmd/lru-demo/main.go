@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+const (
+	cacheSize   = 5
+	dataSetSize = 1000_000_00
+)
+
+func main() {
+	elementsToCache := []string{"Terry", "Tee", "Dog", "Terry", "Car", "Terry"}
+
+	cache := lru.New[string, string](cacheSize)
+
+	for _, e := range elementsToCache {
+		cache.Check(e)
+		cache.Display()
+	}
+
+	benchmarkLRUCache()
+}
+
+func benchmarkLRUCache() {
+	cache := lru.New[string, string](cacheSize)
+
+	// Generate a large data set
+	dataSet := generateLargeDataSet(dataSetSize)
+
+	// Fill the cache with the large data set
+	start := time.Now()
+	for _, e := range dataSet {
+		cache.Check(e)
+	}
+	fillElapsed := time.Since(start)
+
+	// Measure the time taken to find one element in the cache hash
+	randomIndex := rand.Intn(len(dataSet))
+	searchElement := dataSet[randomIndex]
+
+	start = time.Now()
+	_, found := cache.Hash[searchElement]
+	searchElapsed := time.Since(start)
+
+	if !found {
+		os.Exit(1)
+	}
+
+	fmt.Printf("Time taken to fill cache with %d elements: %s\n", dataSetSize, fillElapsed)
+	fmt.Printf("Time taken to find element in cache hash: %s\n", searchElapsed)
+}
+
+// generateLargeDataSet generates a large data set for benchmarking purposes.
+func generateLargeDataSet(size int) []string {
+	dataSet := make([]string, size)
+	for i := 0; i < size; i++ {
+		dataSet[i] = fmt.Sprintf("Element%d", i)
+	}
+	return dataSet
+}
@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"cmp"
+	"slices"
+)
+
+// OpType is the kind of a trace operation.
+type OpType int
+
+const (
+	// OpGet looks the key up. A miss does not insert it; follow it with an
+	// OpSet to model a read-through cache.
+	OpGet OpType = iota
+	// OpSet stores the key.
+	OpSet
+	// OpDelete removes the key.
+	OpDelete
+)
+
+// Op is a single operation of a trace replayed by ComparePolicies.
+type Op[K comparable] struct {
+	Key  K
+	Type OpType
+}
+
+// PolicyReport describes how an eviction policy fared on a trace.
+type PolicyReport struct {
+	// Policy is the name of the policy, see EvictionPolicy.String.
+	Policy string
+	// Stats holds the hits, misses, evictions and insertions of the run.
+	Stats Stats
+	// HitRate is Stats.HitRate, for convenience.
+	HitRate float64
+	// AvgEvictedRank is the average frequency rank of the evicted keys,
+	// where the key used most often in the whole trace has rank 1. A low
+	// rank means the policy evicted popular keys. It is 0 when nothing was
+	// evicted.
+	AvgEvictedRank float64
+}
+
+// ComparePolicies replays trace against a cache of the given capacity for
+// each of policies and returns a report per policy, in the same order. Only
+// keys are stored, so traces of large values can be simulated cheaply.
+func ComparePolicies[K comparable](trace []Op[K], policies []EvictionPolicy, capacity int) []PolicyReport {
+	ranks := frequencyRanks(trace)
+
+	reports := make([]PolicyReport, 0, len(policies))
+	for _, p := range policies {
+		var (
+			deleting  bool
+			evicted   int
+			rankTotal int
+		)
+		cache := New(capacity,
+			WithEvictionPolicy[K, struct{}](p),
+			WithOnEvict(func(key K, _ struct{}) {
+				if !deleting {
+					evicted++
+					rankTotal += ranks[key]
+				}
+			}),
+		)
+
+		for _, op := range trace {
+			switch op.Type {
+			case OpGet:
+				cache.Get(op.Key)
+			case OpSet:
+				cache.Set(op.Key, struct{}{})
+			case OpDelete:
+				deleting = true
+				cache.Delete(op.Key)
+				deleting = false
+			}
+		}
+
+		report := PolicyReport{Policy: p.String(), Stats: cache.Stats()}
+		report.HitRate = report.Stats.HitRate()
+		if evicted > 0 {
+			report.AvgEvictedRank = float64(rankTotal) / float64(evicted)
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// frequencyRanks ranks the keys of trace by how often they occur, starting at
+// 1 for the most frequent one. Keys occurring equally often are ranked by
+// their first occurrence.
+func frequencyRanks[K comparable](trace []Op[K]) map[K]int {
+	counts := map[K]int{}
+	var keys []K
+	for _, op := range trace {
+		if counts[op.Key] == 0 {
+			keys = append(keys, op.Key)
+		}
+		counts[op.Key]++
+	}
+
+	slices.SortStableFunc(keys, func(a, b K) int {
+		return cmp.Compare(counts[b], counts[a])
+	})
+
+	ranks := make(map[K]int, len(keys))
+	for i, key := range keys {
+		ranks[key] = i + 1
+	}
+	return ranks
+}
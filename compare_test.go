@@ -0,0 +1,42 @@
+package lru
+
+import "testing"
+
+func TestComparePolicies(t *testing.T) {
+	trace := []Op[string]{
+		{"Dog", OpSet}, {"Dog", OpGet}, {"Dog", OpGet}, {"Dog", OpGet},
+		{"Cat", OpSet}, {"Soda", OpSet},
+		{"Dog", OpGet}, {"Soda", OpDelete},
+	}
+
+	reports := ComparePolicies(trace, []EvictionPolicy{PolicyLRU, PolicyLFU}, 2)
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 reports, but got: %d", len(reports))
+	}
+
+	// LRU evicts the popular Dog, LFU the unpopular Cat.
+	lru, lfu := reports[0], reports[1]
+	if lru.Policy != "LRU" || lru.Stats.Hits != 3 || lru.Stats.Evictions != 1 || lru.AvgEvictedRank != 1 {
+		t.Errorf("Unexpected LRU report: %+v", lru)
+	}
+	if lfu.Policy != "LFU" || lfu.Stats.Hits != 4 || lfu.Stats.Evictions != 1 || lfu.AvgEvictedRank != 3 {
+		t.Errorf("Unexpected LFU report: %+v", lfu)
+	}
+	if lfu.HitRate != 1 {
+		t.Errorf("Expected LFU to hit every Get, but got: %v", lfu.HitRate)
+	}
+}
+
+func TestEvictionPolicyString(t *testing.T) {
+	for policy, expected := range map[EvictionPolicy]string{
+		PolicyLRU:      "LRU",
+		PolicyClock:    "CLOCK",
+		PolicyARC:      "ARC",
+		PolicyTwoQueue: "2Q",
+		PolicyLRUK(2):  "LRU-2",
+	} {
+		if name := policy.String(); name != expected {
+			t.Errorf("Expected %s, but got: %s", expected, name)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package lru
+
+import "context"
+
+// GetContext is Get for callers with a context. On a miss, the values of ctx
+// are passed to the loader configured with WithLoaderContext. If ctx is done
+// before the loader returns, GetContext stops waiting and returns ctx.Err(),
+// and the loaded value is left to the other callers waiting for it to cache;
+// a loader error is returned as is. Without a loader, a miss is reported with a nil
+// error.
+func (c *Cache[K, V]) GetContext(ctx context.Context, key K) (V, bool, error) {
+	value, err := c.lockedFetch(key)
+	if !c.shouldLoad(err) {
+		return value, err == nil, nil
+	}
+
+	value, err = c.load(ctx, key, c.lockedStoreLoaded)
+	if err != nil {
+		return value, false, err
+	}
+	return value, true, nil
+}
+
+// SetContext is Set for callers with a context, which is passed to the
+// write-through function configured with WithWriteThroughContext. The cache
+// itself does not check ctx.
+func (c *Cache[K, V]) SetContext(ctx context.Context, key K, value V) error {
+	if err := waitWrite(ctx, c.limiter); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.write(ctx, key, value, c.defaultTTL, 0, 1)
+}
@@ -0,0 +1,154 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type requestIDKey struct{}
+
+func TestGetContext(t *testing.T) {
+	var loadedFor string
+	cache := New(2, WithLoaderContext(func(ctx context.Context, key string) (int, error) {
+		loadedFor, _ = ctx.Value(requestIDKey{}).(string)
+		return len(key), nil
+	}))
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	if value, found, err := cache.GetContext(ctx, "Dog"); err != nil || !found || value != 3 {
+		t.Errorf("Expected loaded (3, true, nil), but got: (%d, %t, %v)", value, found, err)
+	}
+	if loadedFor != "req-1" {
+		t.Errorf("Expected the loader to get the caller's context, but got request: %q", loadedFor)
+	}
+
+	// Hits never reach the loader, so a done context does not matter.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if value, found, err := cache.GetContext(canceled, "Dog"); err != nil || !found || value != 3 {
+		t.Errorf("Expected cached (3, true, nil), but got: (%d, %t, %v)", value, found, err)
+	}
+}
+
+func TestGetContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	cache := NewSync(2, WithLoader(func(key string) (int, error) {
+		<-release
+		return len(key), nil
+	}))
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, found, err := cache.GetContext(ctx, "Dog")
+	if found || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected (false, context.Canceled), but got: (%t, %v)", found, err)
+	}
+}
+
+// TestGetContextCanceledThenWrite checks that a load whose caller gave up does
+// not touch the cache afterwards, which would race with the caller's own use
+// of a plain Cache. Run with -race.
+func TestGetContextCanceledThenWrite(t *testing.T) {
+	release := make(chan struct{})
+	loaded := make(chan struct{})
+	cache := New(2, WithLoader(func(key string) (int, error) {
+		defer close(loaded)
+		<-release
+		return len(key), nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, _, err := cache.GetContext(ctx, "Dog")
+		done <- err
+	}()
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, but got: %v", err)
+	}
+
+	close(release)
+	for i := 0; i < 100; i++ {
+		cache.Set("Cat", i)
+	}
+	<-loaded
+	time.Sleep(10 * time.Millisecond)
+	cache.Set("Soda", 1)
+
+	if cache.Contains("Dog") {
+		t.Error("Expected the abandoned load not to be cached")
+	}
+}
+
+func TestGetContextSharedLoadOutlivesCanceledCaller(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var calls atomic.Int32
+	cache := NewSync(2, WithLoaderContext(func(ctx context.Context, key string) (int, error) {
+		calls.Add(1)
+		close(started)
+		<-release
+		return len(key), ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, _, err := cache.GetContext(ctx, "Dog")
+		first <- err
+	}()
+	<-started
+
+	type result struct {
+		value int
+		err   error
+	}
+	second := make(chan result)
+	go func() {
+		value, _, err := cache.GetContext(context.Background(), "Dog")
+		second <- result{value, err}
+	}()
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the first caller to get context.Canceled, but got: %v", err)
+	}
+	// Give the second caller time to join the load.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if r := <-second; r.value != 3 || r.err != nil {
+		t.Errorf("Expected the second caller to get (3, nil), but got: (%d, %v)", r.value, r.err)
+	}
+	if value, found := cache.Peek("Dog"); !found || value != 3 {
+		t.Errorf("Expected the loaded value to be cached, but got: (%d, %t)", value, found)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected the loader to be called once, but got: %d", calls.Load())
+	}
+}
+
+func TestSetContext(t *testing.T) {
+	cache := New(2, WithWriteThroughContext(func(ctx context.Context, key string, value int) error {
+		return ctx.Err()
+	}))
+
+	if err := cache.SetContext(context.Background(), "Dog", 1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := cache.SetContext(ctx, "Cat", 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the write-through error, but got: %v", err)
+	}
+	if cache.Contains("Cat") {
+		t.Errorf("Expected a failed write not to be cached")
+	}
+}
@@ -0,0 +1,69 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidCost is returned when an entry is given a cost that is not
+	// positive.
+	ErrInvalidCost = errors.New("lru: cost must be positive")
+
+	// ErrCostTooHigh is returned when an entry costs more than the capacity
+	// of the whole cache. It matches ErrCacheFull.
+	ErrCostTooHigh = fmt.Errorf("%w: cost exceeds cache capacity", ErrCacheFull)
+)
+
+// SetWithCost stores value under key like Set, but the entry takes cost units
+// of the cache capacity instead of one. Entries are evicted until the total
+// cost of the cache, including the new entry, fits within its capacity. Set
+// is the same as SetWithCost with a cost of 1, so a cache which only uses Set
+// holds capacity entries.
+func (c *Cache[K, V]) SetWithCost(key K, value V, cost int) error {
+	if err := waitWrite(context.Background(), c.limiter); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cost <= 0 {
+		return ErrInvalidCost
+	}
+	if cost > c.capacity {
+		return ErrCostTooHigh
+	}
+
+	return c.write(context.Background(), key, value, c.defaultTTL, 0, cost)
+}
+
+// Cost returns the total cost of all entries in the cache.
+func (c *Cache[K, V]) Cost() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.totalCost
+}
+
+// reweigh updates the cost and size of a cached node after its value changed,
+// evicting entries if the cache no longer fits.
+func (c *Cache[K, V]) reweigh(node *Node[K, V], cost int) {
+	c.totalCost += cost - node.cost
+	node.cost = cost
+
+	size := c.sizeOf(node.Key, node.Value)
+	c.stats.bytes.Add(size - node.size)
+	node.size = size
+
+	for c.overCapacity() {
+		c.evict(nil)
+	}
+}
+
+// overCapacity reports whether the cache holds more than its capacity or, if
+// set, its byte limit allows.
+func (c *Cache[K, V]) overCapacity() bool {
+	return c.totalCost > c.capacity || (c.stats.maxBytes > 0 && c.stats.bytes.Load() > c.stats.maxBytes)
+}
@@ -0,0 +1,101 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetWithCost(t *testing.T) {
+	cache := New[string, string](10)
+
+	cache.SetWithCost("Dog", "Woof", 4)
+	cache.SetWithCost("Cat", "Meow", 3)
+	cache.Set("Soda", "Fizz")
+
+	if cache.Cost() != 8 || cache.Len() != 3 {
+		t.Errorf("Expected cost 8 over 3 entries, but got: %d over %d", cache.Cost(), cache.Len())
+	}
+
+	// Tee needs 5 units: Dog alone frees enough.
+	cache.SetWithCost("Tee", "Leaf", 5)
+	expectedCacheState := []string{"Tee", "Soda", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	// Car needs the whole cache.
+	cache.SetWithCost("Car", "Vroom", 10)
+	expectedCacheState = []string{"Car"}
+	actualCacheState = getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if cache.Cost() != 10 {
+		t.Errorf("Expected cost 10, but got: %d", cache.Cost())
+	}
+}
+
+func TestSetWithCostUpdate(t *testing.T) {
+	cache := New[string, string](10)
+
+	cache.SetWithCost("Dog", "Woof", 4)
+	cache.SetWithCost("Cat", "Meow", 3)
+	cache.SetWithCost("Soda", "Fizz", 2)
+
+	// Growing Soda pushes out the least recently used entries, not Soda.
+	cache.SetWithCost("Soda", "Fizzy", 6)
+	expectedCacheState := []string{"Soda", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if cache.Cost() != 9 {
+		t.Errorf("Expected cost 9, but got: %d", cache.Cost())
+	}
+
+	// Set brings the cost back down to 1.
+	cache.Set("Soda", "Flat")
+	if cache.Cost() != 4 {
+		t.Errorf("Expected cost 4, but got: %d", cache.Cost())
+	}
+
+	cache.Delete("Cat")
+	if cache.Cost() != 1 {
+		t.Errorf("Expected cost 1 after Delete, but got: %d", cache.Cost())
+	}
+	cache.Clear()
+	if cache.Cost() != 0 {
+		t.Errorf("Expected cost 0 after Clear, but got: %d", cache.Cost())
+	}
+}
+
+func TestSetWithCostInvalid(t *testing.T) {
+	cache := New[string, string](10)
+	cache.Set("Dog", "Woof")
+
+	if err := cache.SetWithCost("Cat", "Meow", 0); !errors.Is(err, ErrInvalidCost) {
+		t.Errorf("Expected ErrInvalidCost, but got: %v", err)
+	}
+	if err := cache.SetWithCost("Cat", "Meow", 11); !errors.Is(err, ErrCostTooHigh) {
+		t.Errorf("Expected ErrCostTooHigh, but got: %v", err)
+	}
+	if cache.Contains("Cat") || !cache.Contains("Dog") {
+		t.Errorf("Expected rejected entries to leave the cache unchanged")
+	}
+}
+
+func TestResizeWithCost(t *testing.T) {
+	cache := New[string, string](10)
+
+	cache.SetWithCost("Dog", "Woof", 4)
+	cache.SetWithCost("Cat", "Meow", 3)
+	cache.SetWithCost("Soda", "Fizz", 2)
+
+	cache.Resize(5)
+	expectedCacheState := []string{"Soda", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
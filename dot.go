@@ -0,0 +1,67 @@
+package lru
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dot returns the cache list in the Graphviz DOT language, for checking its
+// links visually. Every entry is a node labelled with its key, drawn between
+// the Head and Tail sentinels, which are boxes. Every Right pointer is an edge
+// labelled R and every Left pointer one labelled L, so a sound list shows
+// each pair of neighbours linked both ways. The list is walked from Head for
+// at most Len entries, so a broken list cannot loop forever.
+func (c *Cache[K, V]) Dot() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var b strings.Builder
+	b.WriteString("digraph lru {\n\trankdir=LR;\n\tnode [shape=ellipse];\n")
+
+	// Collect the nodes first so every pointer can be named, even one to a
+	// node outside the walked list.
+	ids := map[*Node[K, V]]int{}
+	var nodes []*Node[K, V]
+	name := func(node *Node[K, V]) int {
+		id, ok := ids[node]
+		if !ok {
+			id = len(ids)
+			ids[node] = id
+			nodes = append(nodes, node)
+		}
+		return id
+	}
+
+	name(c.LinkedList.Head)
+	node := c.LinkedList.Head.Right
+	for i := 0; i < c.LinkedList.Length && node != nil && node != c.LinkedList.Tail; i++ {
+		name(node)
+		node = node.Right
+	}
+	name(c.LinkedList.Tail)
+
+	var edges strings.Builder
+	for i := 0; i < len(nodes); i++ {
+		node := nodes[i]
+		if node.Right != nil {
+			fmt.Fprintf(&edges, "\tn%d -> n%d [label=\"R\"];\n", i, name(node.Right))
+		}
+		if node.Left != nil {
+			fmt.Fprintf(&edges, "\tn%d -> n%d [label=\"L\"];\n", i, name(node.Left))
+		}
+	}
+
+	for i, node := range nodes {
+		switch node {
+		case c.LinkedList.Head:
+			fmt.Fprintf(&b, "\tn%d [label=\"Head\", shape=box];\n", i)
+		case c.LinkedList.Tail:
+			fmt.Fprintf(&b, "\tn%d [label=\"Tail\", shape=box];\n", i)
+		default:
+			fmt.Fprintf(&b, "\tn%d [label=%q];\n", i, fmt.Sprint(node.Key))
+		}
+	}
+	b.WriteString(edges.String())
+	b.WriteString("}\n")
+	return b.String()
+}
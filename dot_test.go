@@ -0,0 +1,36 @@
+package lru
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDot(t *testing.T) {
+	cache := New[string, int](3)
+	for i, e := range []string{"Dog", "Cat", "Soda"} {
+		cache.Set(e, i)
+	}
+
+	dot := cache.Dot()
+	if !strings.HasPrefix(dot, "digraph") {
+		t.Errorf("Expected a digraph, but got: %s", dot)
+	}
+	for _, label := range []string{`"Head", shape=box`, `"Tail", shape=box`, `"Dog"`, `"Cat"`, `"Soda"`} {
+		if !strings.Contains(dot, label) {
+			t.Errorf("Expected the graph to contain %s, but got: %s", label, dot)
+		}
+	}
+
+	// Three entries have four links, each drawn in both directions.
+	if edges := strings.Count(dot, "->"); edges != 8 {
+		t.Errorf("Expected 8 edges, but got %d in: %s", edges, dot)
+	}
+	if rights := strings.Count(dot, `[label="R"]`); rights != 4 {
+		t.Errorf("Expected 4 Right edges, but got: %d", rights)
+	}
+
+	// Head -> Soda, the most recently used entry.
+	if !strings.Contains(dot, "n0 -> n1 [label=\"R\"]") || !strings.Contains(dot, "n1 [label=\"Soda\"]") {
+		t.Errorf("Expected Head to point to Soda, but got: %s", dot)
+	}
+}
@@ -0,0 +1,58 @@
+package lru
+
+import "errors"
+
+// Drain empties the cache, for a graceful shutdown or to migrate its entries
+// to another cache without fetching them again. It returns the live entries
+// from the least to the most recently used one, so storing them in order with
+// Set restores their recently used order. Every live entry is also passed to
+// the function registered with WithOnFlush, in the same order, and then the
+// cache is cleared like by Clear, which calls the eviction callback for every
+// entry. The errors returned by the flush function are joined together; the
+// cache is cleared and all entries are returned even when some could not be
+// flushed. The lock is held throughout, so no other goroutine sees the cache
+// partly drained.
+func (c *Cache[K, V]) Drain() ([]Entry[K, V], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		entries []Entry[K, V]
+		errs    []error
+	)
+	now := c.now()
+	for node := c.LinkedList.Tail.Left; node != c.LinkedList.Head; node = node.Left {
+		if !node.live(now) {
+			continue
+		}
+		entries = append(entries, Entry[K, V]{Key: node.Key, Value: node.Value})
+		if c.onFlush == nil {
+			continue
+		}
+		if err := c.onFlush(node.Key, node.Value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	c.clearAll()
+	return entries, errors.Join(errs...)
+}
+
+// Drain behaves like Cache.Drain.
+func (s *SyncCache[K, V]) Drain() ([]Entry[K, V], error) {
+	return s.cache.Drain()
+}
+
+// Drain drains every shard in turn, see Cache.Drain, and joins their errors.
+// The entries are ordered from the least to the most recently used one within
+// a shard only, and each shard is drained at a slightly different time.
+func (c *ShardedCache[K, V]) Drain() ([]Entry[K, V], error) {
+	var entries []Entry[K, V]
+	errs := make([]error, len(c.shards))
+	for i, s := range c.shards {
+		var shardEntries []Entry[K, V]
+		shardEntries, errs[i] = s.Drain()
+		entries = append(entries, shardEntries...)
+	}
+	return entries, errors.Join(errs...)
+}
@@ -0,0 +1,75 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	errStoreDown := errors.New("store is down")
+	var calls []string
+	cache := NewSync(4,
+		WithOnFlush(func(key string, value int) error {
+			calls = append(calls, "flush "+key)
+			if key == "Cat" {
+				return fmt.Errorf("flushing %s: %w", key, errStoreDown)
+			}
+			return nil
+		}),
+		WithOnEvict(func(key string, value int) {
+			calls = append(calls, "evict "+key)
+		}),
+	)
+	clock := newTestClock(cache.cache)
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.SetWithTTL("Soda", 3, time.Second)
+	clock.Advance(2 * time.Second)
+
+	entries, err := cache.Drain()
+	if !errors.Is(err, errStoreDown) {
+		t.Errorf("Expected the flush error, but got: %v", err)
+	}
+	if keys := entryKeys(entries); !equalSlice([]string{"Dog", "Cat"}, keys) {
+		t.Errorf("Expected the live entries from the least recently used one, but got: %v", keys)
+	}
+
+	// The expired entry is evicted without being flushed.
+	expectedCalls := []string{"flush Dog", "flush Cat", "evict Dog", "evict Cat", "evict Soda"}
+	if !equalSlice(expectedCalls, calls) {
+		t.Errorf("Expected calls: %v, but got: %v", expectedCalls, calls)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected an empty cache, but got Len: %d", cache.Len())
+	}
+}
+
+func TestDrainMigration(t *testing.T) {
+	old := New[string, int](4)
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee"} {
+		old.Set(e, i)
+	}
+	old.Get("Dog")
+
+	entries, err := old.Drain()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrated := New[string, int](8)
+	for _, entry := range entries {
+		migrated.Set(entry.Key, entry.Value)
+	}
+
+	expectedCacheState := []string{"Dog", "Tee", "Soda", "Cat"}
+	actualCacheState := getCacheState(migrated)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if old.Len() != 0 {
+		t.Errorf("Expected the drained cache to be empty, but got Len: %d", old.Len())
+	}
+}
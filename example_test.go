@@ -0,0 +1,24 @@
+package lru_test
+
+import (
+	"fmt"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+func ExampleStats_HitRate() {
+	cache := lru.New[string, int](2)
+	cache.Set("Dog", 1)
+
+	cache.Get("Dog")
+	cache.Get("Dog")
+	cache.Get("Dog")
+	cache.Get("Cat")
+
+	stats := cache.Stats()
+	fmt.Printf("hit rate: %.2f\n", stats.HitRate())
+	fmt.Printf("miss rate: %.2f\n", stats.MissRate())
+	// Output:
+	// hit rate: 0.75
+	// miss rate: 0.25
+}
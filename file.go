@@ -0,0 +1,68 @@
+package lru
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Save writes the cache to the file at path in the format of MarshalBinary.
+// The file is replaced atomically: the encoding is written to a temporary
+// file in the same directory, which is synced and then renamed to path, so
+// path holds either the previous or the new contents even if the process
+// crashes. A crash may leave the temporary file behind.
+func (c *Cache[K, V]) Save(path string) error {
+	data, err := c.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic replaces the file at path with data through a synced
+// temporary file and a rename.
+func writeFileAtomic(path string, data []byte) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Load reads a cache written by Save from the file at path. The cache is
+// created with opts and takes over the capacity and entries stored in the
+// file. A file written by an incompatible version of Save is reported with
+// ErrUnsupportedVersion.
+func Load[K comparable, V any](path string, opts ...Option[K, V]) (*Cache[K, V], error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := New(1, opts...)
+	if err := c.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("lru: loading %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save behaves like Cache.Save.
+func (s *SyncCache[K, V]) Save(path string) error {
+	return s.cache.Save(path)
+}
@@ -0,0 +1,107 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.bin")
+
+	cache := New[string, string](3)
+	for _, e := range []string{"Dog", "Cat", "Soda", "Tee"} {
+		cache.Set(e, e+"!")
+	}
+	if err := cache.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load[string, string](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Cap() != 3 || !equalSlice(cache.Keys(), loaded.Keys()) || !equalSlice(cache.Values(), loaded.Values()) {
+		t.Errorf("Expected entries: %v, but got: %v", cache.Entries(), loaded.Entries())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files to be left behind, but got %d files", len(entries))
+	}
+
+	sync := NewSync[string, string](3)
+	sync.Set("Dog", "Woof")
+	if err := sync.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := Load[string, string](path); err != nil || !equalSlice([]string{"Dog"}, loaded.Keys()) {
+		t.Errorf("Expected SyncCache.Save to replace the file, but got: %v, %v", loaded, err)
+	}
+
+	if _, err := Load[string, string](filepath.Join(t.TempDir(), "missing.bin")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist, but got: %v", err)
+	}
+}
+
+func TestLoadVersionMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.bin")
+
+	cache := New[string, string](3)
+	cache.Set("Dog", "Woof")
+	data, err := cache.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bump the version byte, as a future format would.
+	data[0]++
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load[string, string](path)
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Expected ErrUnsupportedVersion, but got: %v", err)
+	}
+}
+
+// saveLoopEnv makes the test binary save caches to the given path in a loop,
+// until it is killed.
+const saveLoopEnv = "LRU_TEST_SAVE_LOOP"
+
+func TestSaveCrash(t *testing.T) {
+	if path := os.Getenv(saveLoopEnv); path != "" {
+		cache := New[string, string](50000)
+		for i := 0; ; i++ {
+			cache.Set(fmt.Sprintf("Element%d", i%100000), fmt.Sprintf("Value%d", i))
+			if i%1000 == 0 {
+				cache.Save(path)
+			}
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "cache.bin")
+	for run := 0; run < 5; run++ {
+		cmd := exec.Command(os.Args[0], "-test.run", "^TestSaveCrash$")
+		cmd.Env = append(os.Environ(), saveLoopEnv+"="+path)
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Duration(100+run*50) * time.Millisecond)
+		cmd.Process.Kill()
+		cmd.Wait()
+
+		// Whenever the process was killed, the file is a complete encoding.
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if _, err := Load[string, string](path); err != nil {
+			t.Fatalf("Expected the file to survive the crash, but got: %v", err)
+		}
+	}
+}
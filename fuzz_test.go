@@ -0,0 +1,72 @@
+package lru
+
+import "testing"
+
+// checkInvariants fails t when the list and the hash of cache disagree.
+func checkInvariants[K comparable, V any](t *testing.T, cache *Cache[K, V]) {
+	t.Helper()
+
+	list := cache.LinkedList
+	if cache.Len() > cache.Cap() {
+		t.Fatalf("Len %d exceeds Cap %d", cache.Len(), cache.Cap())
+	}
+	if list.Length != len(cache.Hash) {
+		t.Fatalf("list length %d differs from hash size %d", list.Length, len(cache.Hash))
+	}
+	if list.Head.Right.Left != list.Head || list.Tail.Left.Right != list.Tail {
+		t.Fatalf("head or tail sentinel is not linked back")
+	}
+
+	reachable := 0
+	for node := list.Head.Right; node != list.Tail; node = node.Right {
+		if node.Right.Left != node {
+			t.Fatalf("%v is not linked back from its right neighbour", node.Key)
+		}
+		if cache.Hash[node.Key] != node {
+			t.Fatalf("%v is linked but not hashed", node.Key)
+		}
+		reachable++
+		if reachable > list.Length {
+			t.Fatalf("list has a cycle or more nodes than its length %d", list.Length)
+		}
+	}
+	if reachable != list.Length {
+		t.Fatalf("%d nodes are reachable from the head, but the length is %d", reachable, list.Length)
+	}
+}
+
+// FuzzCache runs a script of operations against a small cache. The first
+// byte picks the capacity; every following operation takes three bytes: the
+// operation, the key length from 0 to 2 and the key, which is made of the
+// lowest bits of the key byte so that keys repeat often.
+func FuzzCache(f *testing.F) {
+	f.Add([]byte{3})
+	f.Add([]byte{1, 0, 0, 'a', 0, 1, 'a', 0, 1, 'b', 0, 1, 'a'})
+	f.Add([]byte{2, 0, 0, 0, 0, 0, 0, 1, 0, 0, 2, 0, 0, 3, 0, 0})
+	f.Add([]byte{2, 3, 1, 'a', 3, 1, 'b', 3, 1, 'c', 1, 1, 'a', 2, 1, 'c'})
+	f.Add([]byte{4, 0, 2, 'a', 0, 2, 'a', 0, 2, 'a', 2, 2, 'a', 3, 2, 'a'})
+
+	f.Fuzz(func(t *testing.T, script []byte) {
+		if len(script) == 0 {
+			return
+		}
+		cache := New[string, int](int(script[0]%8) + 1)
+
+		for i := 1; i+2 < len(script); i += 3 {
+			op, length, b := script[i]%4, int(script[i+1]%3), script[i+2]
+			key := string([]byte{'a' + b&3, 'a' + b>>2&3}[:length])
+
+			switch op {
+			case 0:
+				cache.Set(key, i)
+			case 1:
+				cache.Get(key)
+			case 2:
+				cache.Delete(key)
+			case 3:
+				cache.Check(key)
+			}
+			checkInvariants(t, cache)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package lru
+
+// GhostCache remembers the keys of recently evicted entries, without their
+// values, forgetting the oldest keys once it holds more than its capacity. A
+// lookup of a remembered key, a ghost hit, means the entry would still be
+// cached if the cache were larger, which adaptive policies such as ARC and 2Q
+// use to tune themselves. A GhostCache is not safe for concurrent use.
+type GhostCache[K comparable] struct {
+	keys     *ghostList[K]
+	capacity int
+}
+
+// NewGhostCache creates an empty GhostCache remembering at most capacity
+// keys. A capacity below 1 is raised to 1.
+func NewGhostCache[K comparable](capacity int) *GhostCache[K] {
+	return &GhostCache[K]{keys: newGhostList[K](), capacity: max(capacity, 1)}
+}
+
+// Add remembers key as the most recently evicted one.
+func (g *GhostCache[K]) Add(key K) {
+	g.keys.push(key, g.capacity)
+}
+
+// GhostHit reports whether key is remembered.
+func (g *GhostCache[K]) GhostHit(key K) bool {
+	return g.keys.contains(key)
+}
+
+// Remove forgets key and reports whether it was remembered.
+func (g *GhostCache[K]) Remove(key K) bool {
+	return g.keys.remove(key)
+}
+
+// Len returns the number of remembered keys.
+func (g *GhostCache[K]) Len() int {
+	return g.keys.len()
+}
+
+// Cap returns the maximum number of keys remembered.
+func (g *GhostCache[K]) Cap() int {
+	return g.capacity
+}
+
+// Clear forgets every key.
+func (g *GhostCache[K]) Clear() {
+	g.keys.clear()
+}
+
+// clone returns a copy of g remembering the same keys, or nil for a nil g.
+func (g *GhostCache[K]) clone() *GhostCache[K] {
+	if g == nil {
+		return nil
+	}
+
+	clone := &GhostCache[K]{keys: newGhostList[K](), capacity: g.capacity}
+	for elem := g.keys.keys.Back(); elem != nil; elem = elem.Prev() {
+		clone.Add(elem.Value.(K))
+	}
+	return clone
+}
+
+// WithGhostCache makes the cache remember the keys of up to capacity entries
+// it evicted to make room, see GhostCache. A capacity of 0 remembers twice as
+// many keys as the cache holds entries. Misses on remembered keys are counted
+// in Stats.GhostHits and can be checked with GhostHit; storing a key again
+// forgets it. Entries which are deleted, expire or are cleared are not
+// remembered.
+func WithGhostCache[K comparable, V any](capacity int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		// New replaces a capacity of 0 once the cache capacity is known.
+		c.ghost = &GhostCache[K]{keys: newGhostList[K](), capacity: max(capacity, 0)}
+	}
+}
+
+// GhostHit reports whether key was recently evicted to make room and has not
+// been stored again since. It is always false without WithGhostCache.
+func (c *Cache[K, V]) GhostHit(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.ghostHit(key)
+}
+
+// ghostHit is GhostHit without locking.
+func (c *Cache[K, V]) ghostHit(key K) bool {
+	return c.ghost != nil && c.ghost.GhostHit(key)
+}
+
+func (s *SyncCache[K, V]) GhostHit(key K) bool {
+	return s.cache.GhostHit(key)
+}
+
+func (c *ShardedCache[K, V]) GhostHit(key K) bool {
+	return c.shard(key).GhostHit(key)
+}
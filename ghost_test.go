@@ -0,0 +1,66 @@
+package lru
+
+import "testing"
+
+func TestGhostCache(t *testing.T) {
+	ghost := NewGhostCache[string](2)
+
+	ghost.Add("Dog")
+	ghost.Add("Cat")
+	ghost.Add("Dog")
+	ghost.Add("Soda")
+
+	if ghost.GhostHit("Cat") {
+		t.Errorf("Expected the oldest key to be forgotten")
+	}
+	if !ghost.GhostHit("Dog") || !ghost.GhostHit("Soda") || ghost.Len() != 2 {
+		t.Errorf("Expected Dog and Soda to be remembered, but got Len %d", ghost.Len())
+	}
+	if !ghost.Remove("Dog") || ghost.GhostHit("Dog") {
+		t.Errorf("Expected Remove to forget Dog")
+	}
+
+	ghost.Clear()
+	if ghost.Len() != 0 {
+		t.Errorf("Expected Clear to forget every key, but got Len %d", ghost.Len())
+	}
+}
+
+func TestWithGhostCache(t *testing.T) {
+	cache := New(2, WithGhostCache[string, int](0))
+	if cap := cache.ghost.Cap(); cap != 4 {
+		t.Errorf("Expected the ghost cache to default to twice the capacity, but got: %d", cap)
+	}
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+	cache.Delete("Cat")
+
+	if !cache.GhostHit("Dog") {
+		t.Errorf("Expected the evicted Dog to be a ghost hit")
+	}
+	if cache.GhostHit("Cat") {
+		t.Errorf("Expected the deleted Cat not to be remembered")
+	}
+
+	cache.Get("Dog")
+	cache.Get("Cat")
+	if hits := cache.Stats().GhostHits; hits != 1 {
+		t.Errorf("Expected 1 ghost hit, but got: %d", hits)
+	}
+
+	clone := cache.Clone()
+	if !clone.GhostHit("Dog") {
+		t.Errorf("Expected the clone to remember Dog")
+	}
+
+	cache.Set("Dog", 1)
+	if cache.GhostHit("Dog") || !clone.GhostHit("Dog") {
+		t.Errorf("Expected storing Dog again to forget it in the cache only")
+	}
+
+	if New[string, int](2).GhostHit("Dog") {
+		t.Errorf("Expected no ghost hits without WithGhostCache")
+	}
+}
@@ -0,0 +1,47 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// cacheGob is the gob form of a Cache. Entries are ordered from the least to
+// the most recently used one.
+type cacheGob[K comparable, V any] struct {
+	Capacity int
+	Entries  []Entry[K, V]
+}
+
+// GobEncode encodes the capacity of the cache and its entries, from the least
+// to the most recently used one, so the cache can be written with a
+// gob.Encoder. Expiration times, costs and statistics are not included.
+func (c *Cache[K, V]) GobEncode() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entries := make([]Entry[K, V], 0, c.LinkedList.Length)
+	for node := c.LinkedList.Tail.Left; node != c.LinkedList.Head; node = node.Left {
+		entries = append(entries, Entry[K, V]{Key: node.Key, Value: node.Value})
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cacheGob[K, V]{Capacity: c.capacity, Entries: entries}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the contents of the cache with the entries encoded by
+// GobEncode, keeping their recently used order, and takes over the encoded
+// capacity. Like UnmarshalJSON it can be used on a zero Cache.
+func (c *Cache[K, V]) GobDecode(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var decoded cacheGob[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&decoded); err != nil {
+		return err
+	}
+
+	return c.restore(decoded.Capacity, decoded.Entries)
+}
@@ -0,0 +1,51 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	cache := New[string, string](1000)
+	for i := 0; i < 1000; i++ {
+		cache.Set(fmt.Sprintf("Element%d", i), fmt.Sprintf("Value%d", i))
+	}
+	for i := 0; i < 1000; i += 7 {
+		cache.Get(fmt.Sprintf("Element%d", i))
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cache); err != nil {
+		t.Fatal(err)
+	}
+
+	var restored Cache[string, string]
+	if err := gob.NewDecoder(&buf).Decode(&restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.Len() != 1000 || restored.Cap() != 1000 {
+		t.Errorf("Expected Len and Cap 1000, but got: %d and %d", restored.Len(), restored.Cap())
+	}
+	if !equalSlice(cache.Keys(), restored.Keys()) || !equalSlice(cache.Values(), restored.Values()) {
+		t.Errorf("Expected the recently used order to survive the round trip")
+	}
+}
+
+func TestGobDecodeInvalid(t *testing.T) {
+	var cache Cache[string, int]
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cacheGob[string, int]{Capacity: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.GobDecode(buf.Bytes()); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("Expected ErrInvalidCapacity, but got: %v", err)
+	}
+	if err := cache.GobDecode([]byte("not gob")); err == nil {
+		t.Errorf("Expected an error for malformed data")
+	}
+}
@@ -0,0 +1,117 @@
+// Package http serves an administrative view of an lru cache over HTTP, with
+// stats and content encoded as JSON, and caches the responses of other
+// handlers in an lru cache.
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+// Source is the part of a cache the handler reads and modifies. It is
+// satisfied by lru.Cache and lru.SyncCache.
+type Source[V any] interface {
+	Stats() lru.Stats
+	Keys() []string
+	Peek(key string) (V, bool)
+	TTL(key string) (time.Duration, bool)
+	AccessCount(key string) (int64, bool)
+	Delete(key string) bool
+	Clear()
+}
+
+// Entry is the JSON form of a single cache entry. TTL is the remaining
+// lifetime formatted like time.Duration.String, and empty for an entry that
+// never expires.
+type Entry[V any] struct {
+	Key         string `json:"key"`
+	Value       V      `json:"value"`
+	TTL         string `json:"ttl,omitempty"`
+	AccessCount int64  `json:"access_count"`
+}
+
+type handler[V any] struct {
+	cache Source[V]
+}
+
+// Handler returns an http.Handler serving these routes for c:
+//
+//	GET    /stats         the lru.Stats of c
+//	GET    /keys          all keys, from the most to the least recently used
+//	GET    /entries/{key} the Entry stored under key, without promoting it
+//	DELETE /entries/{key} removes the entry stored under key
+//	POST   /clear         removes all entries
+//
+// Requests are not serialized by the handler, so c must be safe for
+// concurrent use, as lru.Cache, lru.SyncCache and lru.ShardedCache are.
+func Handler[V any](c Source[V]) http.Handler {
+	h := &handler[V]{cache: c}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /stats", h.stats)
+	mux.HandleFunc("GET /keys", h.keys)
+	mux.HandleFunc("GET /entries/{key}", h.getEntry)
+	mux.HandleFunc("DELETE /entries/{key}", h.deleteEntry)
+	mux.HandleFunc("POST /clear", h.clear)
+	return mux
+}
+
+func (h *handler[V]) stats(w http.ResponseWriter, r *http.Request) {
+	stats := h.cache.Stats()
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (h *handler[V]) keys(w http.ResponseWriter, r *http.Request) {
+	keys := h.cache.Keys()
+
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (h *handler[V]) getEntry(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+
+	value, found := h.cache.Peek(key)
+	ttl, _ := h.cache.TTL(key)
+	count, _ := h.cache.AccessCount(key)
+
+	if !found {
+		writeError(w, http.StatusNotFound, "entry not found")
+		return
+	}
+
+	entry := Entry[V]{Key: key, Value: value, AccessCount: count}
+	if ttl >= 0 {
+		entry.TTL = ttl.String()
+	}
+	writeJSON(w, http.StatusOK, entry)
+}
+
+func (h *handler[V]) deleteEntry(w http.ResponseWriter, r *http.Request) {
+	deleted := h.cache.Delete(r.PathValue("key"))
+
+	if !deleted {
+		writeError(w, http.StatusNotFound, "entry not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler[V]) clear(w http.ResponseWriter, r *http.Request) {
+	h.cache.Clear()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
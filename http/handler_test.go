@@ -0,0 +1,88 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+func serve(t *testing.T, h http.Handler, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	// Method mismatches are answered by http.ServeMux in plain text.
+	if rec.Body.Len() > 0 && rec.Code != http.StatusMethodNotAllowed && rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("%s %s: expected a JSON Content-Type, but got: %q", method, target, rec.Header().Get("Content-Type"))
+	}
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	if err := json.NewDecoder(rec.Body).Decode(&v); err != nil {
+		t.Fatalf("Unexpected error decoding %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
+
+func TestHandler(t *testing.T) {
+	cache := lru.New[string, int](3)
+	cache.Set("Dog", 1)
+	cache.SetWithTTL("Cat", 2, time.Hour)
+	cache.Get("Dog")
+	cache.Get("Soda")
+	h := Handler[int](cache)
+
+	rec := serve(t, h, "GET", "/stats")
+	if stats := decode[lru.Stats](t, rec); rec.Code != http.StatusOK || stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss, but got: %d %+v", rec.Code, stats)
+	}
+
+	rec = serve(t, h, "GET", "/keys")
+	if keys := decode[[]string](t, rec); rec.Code != http.StatusOK || !reflect.DeepEqual(keys, []string{"Dog", "Cat"}) {
+		t.Errorf("Expected keys [Dog Cat], but got: %d %v", rec.Code, keys)
+	}
+
+	rec = serve(t, h, "GET", "/entries/Dog")
+	expected := Entry[int]{Key: "Dog", Value: 1, AccessCount: 1}
+	if entry := decode[Entry[int]](t, rec); rec.Code != http.StatusOK || entry != expected {
+		t.Errorf("Expected entry %+v, but got: %d %+v", expected, rec.Code, entry)
+	}
+
+	rec = serve(t, h, "GET", "/entries/Cat")
+	if entry := decode[Entry[int]](t, rec); rec.Code != http.StatusOK || entry.Value != 2 || entry.TTL == "" {
+		t.Errorf("Expected Cat with a remaining TTL, but got: %d %+v", rec.Code, entry)
+	}
+
+	if rec = serve(t, h, "GET", "/entries/Soda"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing entry, but got: %d", rec.Code)
+	}
+
+	// Reading entries must not promote them.
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"Dog", "Cat"}) {
+		t.Errorf("Expected keys [Dog Cat], but got: %v", keys)
+	}
+
+	if rec = serve(t, h, "DELETE", "/entries/Dog"); rec.Code != http.StatusNoContent || cache.Contains("Dog") {
+		t.Errorf("Expected Dog to be deleted, but got: %d", rec.Code)
+	}
+	if rec = serve(t, h, "DELETE", "/entries/Dog"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 deleting a missing entry, but got: %d", rec.Code)
+	}
+
+	if rec = serve(t, h, "POST", "/clear"); rec.Code != http.StatusNoContent || cache.Len() != 0 {
+		t.Errorf("Expected the cache to be cleared, but got: %d with Len %d", rec.Code, cache.Len())
+	}
+
+	if rec = serve(t, h, "POST", "/stats"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for a wrong method, but got: %d", rec.Code)
+	}
+}
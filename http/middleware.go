@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// Response is an HTTP response stored by CachingMiddleware.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ResponseCache is the part of a cache CachingMiddleware stores responses in.
+// It is satisfied by lru.Cache, lru.SyncCache and lru.ShardedCache.
+type ResponseCache interface {
+	Get(key string) (Response, bool)
+	SetWithTTL(key string, value Response, ttl time.Duration) error
+}
+
+// CachingMiddleware returns a middleware caching the responses of the
+// wrapped handler in c for ttl, where a ttl of 0 means forever. Only GET and
+// HEAD requests answered with 200 OK are cached, under the key returned by
+// keyFn; a nil keyFn uses RequestKey. Responses carry an X-Cache header of
+// HIT when served from c and MISS otherwise.
+//
+// A request sent with Cache-Control: no-cache skips the lookup, and the fresh
+// response replaces the cached one. The wrapped handler writes to a
+// httptest.ResponseRecorder, so its response is buffered in full and
+// streaming and http.Flusher do not work through the middleware.
+func CachingMiddleware(c ResponseCache, ttl time.Duration, keyFn func(*http.Request) string) func(http.Handler) http.Handler {
+	if keyFn == nil {
+		keyFn = RequestKey
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := keyFn(r)
+			if !hasDirective(r.Header, "no-cache") {
+				if resp, ok := c.Get(key); ok {
+					writeResponse(w, resp, "HIT")
+					return
+				}
+			}
+
+			rec := httptest.NewRecorder()
+			next.ServeHTTP(rec, r)
+			resp := Response{StatusCode: rec.Code, Header: rec.Header().Clone(), Body: rec.Body.Bytes()}
+			if resp.StatusCode == http.StatusOK {
+				c.SetWithTTL(key, resp, ttl)
+			}
+			writeResponse(w, resp, "MISS")
+		})
+	}
+}
+
+// RequestKey returns the method and request URI of r, such as
+// "GET:/users?page=2".
+func RequestKey(r *http.Request) string {
+	return r.Method + ":" + r.URL.RequestURI()
+}
+
+// hasDirective reports whether the Cache-Control header of h holds directive.
+func hasDirective(h http.Header, directive string) bool {
+	for _, value := range h.Values("Cache-Control") {
+		for _, d := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(d), directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeResponse(w http.ResponseWriter, resp Response, cacheStatus string) {
+	header := w.Header()
+	for name, values := range resp.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	header.Set("X-Cache", cacheStatus)
+	w.WriteHeader(resp.StatusCode)
+	w.Write(resp.Body)
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+func TestCachingMiddleware(t *testing.T) {
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintf(w, "%s #%d", r.URL.Path, calls)
+	})
+	cache := lru.NewSync[string, Response](10)
+	h := CachingMiddleware(cache, time.Minute, nil)(next)
+
+	get := func(target string, header http.Header) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("GET", target, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		target string
+		header http.Header
+		status int
+		body   string
+		cache  string
+	}{
+		{"/dog", nil, http.StatusOK, "/dog #1", "MISS"},
+		{"/dog", nil, http.StatusOK, "/dog #1", "HIT"},
+		{"/dog?bark=1", nil, http.StatusOK, "/dog #2", "MISS"},
+		{"/dog", http.Header{"Cache-Control": {"max-age=0, no-cache"}}, http.StatusOK, "/dog #3", "MISS"},
+		{"/dog", nil, http.StatusOK, "/dog #3", "HIT"},
+		{"/missing", nil, http.StatusNotFound, "404 page not found\n", "MISS"},
+		{"/missing", nil, http.StatusNotFound, "404 page not found\n", "MISS"},
+	}
+	for _, tt := range tests {
+		rec := get(tt.target, tt.header)
+		if rec.Code != tt.status || rec.Body.String() != tt.body || rec.Header().Get("X-Cache") != tt.cache {
+			t.Errorf("GET %s: expected %d %q with X-Cache %s, but got: %d %q with X-Cache %s",
+				tt.target, tt.status, tt.body, tt.cache, rec.Code, rec.Body.String(), rec.Header().Get("X-Cache"))
+		}
+		if tt.status == http.StatusOK && rec.Header().Get("Content-Type") != "text/plain" {
+			t.Errorf("GET %s: expected the Content-Type to be kept, but got: %q", tt.target, rec.Header().Get("Content-Type"))
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/dog", nil))
+	if rec.Body.String() != "/dog #6" || rec.Header().Get("X-Cache") != "" {
+		t.Errorf("Expected POST to bypass the cache, but got: %q with X-Cache %q", rec.Body.String(), rec.Header().Get("X-Cache"))
+	}
+}
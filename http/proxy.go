@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ProxyOption configures the handler created by NewProxyCache.
+type ProxyOption func(*proxyConfig)
+
+type proxyConfig struct {
+	contentTypes []string
+}
+
+// WithCacheableTypes sets the media types of the responses NewProxyCache
+// caches, such as "application/json". Parameters like charset are ignored
+// when matching. The default is application/json and text/html.
+func WithCacheableTypes(types ...string) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.contentTypes = types
+	}
+}
+
+type proxyCache struct {
+	proxy        *httputil.ReverseProxy
+	cache        ResponseCache
+	ttl          time.Duration
+	contentTypes []string
+}
+
+// NewProxyCache returns a reverse proxy to target, built on
+// httputil.NewSingleHostReverseProxy, which serves cacheable responses from
+// c for ttl, where a ttl of 0 means forever. A response is cacheable when it
+// answers a GET request with 200 OK and one of the media types set with
+// WithCacheableTypes, and neither the request nor the response carries
+// Cache-Control: no-store. Responses are cached under their method, path and
+// query, as in "GET:/users?page=2". A response with a Vary header is cached
+// for the values the request had for the listed headers, and one varying on
+// "*" is not cached. As with CachingMiddleware, responses carry an X-Cache
+// header of HIT or MISS, and Cache-Control: no-cache on a request skips the
+// lookup.
+//
+// Cacheable responses are read in full before being forwarded, so they are
+// not streamed; other responses are proxied as they are.
+func NewProxyCache(target *url.URL, c ResponseCache, ttl time.Duration, opts ...ProxyOption) http.Handler {
+	cfg := proxyConfig{contentTypes: []string{"application/json", "text/html"}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p := &proxyCache{
+		proxy:        httputil.NewSingleHostReverseProxy(target),
+		cache:        c,
+		ttl:          ttl,
+		contentTypes: cfg.contentTypes,
+	}
+	p.proxy.ModifyResponse = p.store
+	return p
+}
+
+func (p *proxyCache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		p.proxy.ServeHTTP(w, r)
+		return
+	}
+
+	if !hasDirective(r.Header, "no-cache") {
+		if resp, ok := p.lookup(r); ok {
+			writeResponse(w, resp, "HIT")
+			return
+		}
+	}
+
+	w.Header().Set("X-Cache", "MISS")
+	p.proxy.ServeHTTP(w, r)
+}
+
+// lookup returns the cached response for r. The entry under the key of r is
+// either the response itself or, for a response with a Vary header, a marker
+// with no status code holding the Vary header to pick the variant with.
+func (p *proxyCache) lookup(r *http.Request) (Response, bool) {
+	key := proxyKey(r)
+	resp, ok := p.cache.Get(key)
+	if !ok || resp.StatusCode != 0 {
+		return resp, ok
+	}
+	return p.cache.Get(variantKey(key, resp.Header.Values("Vary"), r.Header))
+}
+
+// store is the ModifyResponse hook of the proxy, caching res if it is
+// cacheable.
+func (p *proxyCache) store(res *http.Response) error {
+	if !p.cacheable(res) {
+		return nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp := Response{StatusCode: res.StatusCode, Header: res.Header.Clone(), Body: body}
+	key := proxyKey(res.Request)
+	if vary := res.Header.Values("Vary"); len(vary) > 0 {
+		marker := Response{Header: http.Header{"Vary": vary}}
+		p.cache.SetWithTTL(key, marker, p.ttl)
+		key = variantKey(key, vary, res.Request.Header)
+	}
+	p.cache.SetWithTTL(key, resp, p.ttl)
+	return nil
+}
+
+func (p *proxyCache) cacheable(res *http.Response) bool {
+	if res.Request.Method != http.MethodGet || res.StatusCode != http.StatusOK {
+		return false
+	}
+	if hasDirective(res.Header, "no-store") || hasDirective(res.Request.Header, "no-store") {
+		return false
+	}
+	if slices.Contains(varyFields(res.Header.Values("Vary")), "*") {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	return err == nil && slices.Contains(p.contentTypes, mediaType)
+}
+
+func proxyKey(r *http.Request) string {
+	return r.Method + ":" + r.URL.Path + "?" + r.URL.RawQuery
+}
+
+// variantKey extends key with the values header has for the fields listed
+// by vary.
+func variantKey(key string, vary []string, header http.Header) string {
+	var b strings.Builder
+	b.WriteString(key)
+	for _, field := range varyFields(vary) {
+		b.WriteString("\n" + field + ":" + strings.Join(header.Values(field), ","))
+	}
+	return b.String()
+}
+
+// varyFields returns the sorted, canonical header names listed by the Vary
+// header values vary.
+func varyFields(vary []string) []string {
+	var fields []string
+	for _, value := range vary {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, http.CanonicalHeaderKey(field))
+			}
+		}
+	}
+	slices.Sort(fields)
+	return slices.Compact(fields)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+func TestProxyCache(t *testing.T) {
+	calls := 0
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+		case "/private":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Cache-Control", "no-store")
+		case "/lang":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Vary", "Accept-Language")
+		case "/any":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Vary", "*")
+		}
+		fmt.Fprintf(w, "%s %s %s #%d", r.Method, r.URL.RequestURI(), r.Header.Get("Accept-Language"), calls)
+	}))
+	defer backend.Close()
+
+	target, err := url.Parse(backend.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httptest.NewServer(NewProxyCache(target, lru.NewSync[string, Response](10), 0))
+	defer proxy.Close()
+
+	do := func(method, path, lang string) (string, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, proxy.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lang != "" {
+			req.Header.Set("Accept-Language", lang)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body), resp.Header.Get("X-Cache")
+	}
+
+	tests := []struct {
+		method, path, lang string
+		body, cache        string
+	}{
+		{"GET", "/json?id=1", "", "GET /json?id=1  #1", "MISS"},
+		{"GET", "/json?id=1", "", "GET /json?id=1  #1", "HIT"},
+		{"GET", "/json?id=2", "", "GET /json?id=2  #2", "MISS"},
+		{"POST", "/json?id=1", "", "POST /json?id=1  #3", ""},
+		{"GET", "/text", "", "GET /text  #4", "MISS"},
+		{"GET", "/text", "", "GET /text  #5", "MISS"},
+		{"GET", "/private", "", "GET /private  #6", "MISS"},
+		{"GET", "/private", "", "GET /private  #7", "MISS"},
+		{"GET", "/lang", "en", "GET /lang en #8", "MISS"},
+		{"GET", "/lang", "pl", "GET /lang pl #9", "MISS"},
+		{"GET", "/lang", "en", "GET /lang en #8", "HIT"},
+		{"GET", "/lang", "pl", "GET /lang pl #9", "HIT"},
+		{"GET", "/any", "", "GET /any  #10", "MISS"},
+		{"GET", "/any", "", "GET /any  #11", "MISS"},
+	}
+	for _, tt := range tests {
+		if body, cache := do(tt.method, tt.path, tt.lang); body != tt.body || cache != tt.cache {
+			t.Errorf("%s %s: expected %q with X-Cache %q, but got: %q with X-Cache %q",
+				tt.method, tt.path, tt.body, tt.cache, body, cache)
+		}
+	}
+}
+
+func TestProxyCacheTypes(t *testing.T) {
+	calls := 0
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintf(w, "#%d", calls)
+	}))
+	defer backend.Close()
+
+	target, err := url.Parse(backend.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewProxyCache(target, lru.NewSync[string, Response](10), 0, WithCacheableTypes("text/plain"))
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/text", nil))
+		if rec.Body.String() != "#1" {
+			t.Errorf("Expected text/plain to be cached, but got: %q", rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package lru
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// CacheDump is a copy of the state of a cache for debugging, returned by
+// Inspect. Printing it shows a table of the entries.
+type CacheDump[K comparable, V any] struct {
+	Capacity int
+	Length   int
+	// Entries are ordered from the most to the least recently used one.
+	Entries []InspectEntry[K, V]
+}
+
+// InspectEntry is the state of a single entry in a CacheDump. TTL is the
+// remaining lifetime, including any stale window, and -1 for an entry which
+// never expires. Expired entries which were not removed yet are included,
+// with IsExpired set and a TTL of 0.
+type InspectEntry[K comparable, V any] struct {
+	Key            K
+	Value          V
+	AccessCount    int64
+	CreatedAt      time.Time
+	LastAccessedAt time.Time
+	TTL            time.Duration
+	IsExpired      bool
+}
+
+// Inspect returns the state of the cache and of every entry in it, without
+// updating their positions or removing expired entries.
+func (c *Cache[K, V]) Inspect() CacheDump[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.inspect()
+}
+
+// inspect is Inspect without locking.
+func (c *Cache[K, V]) inspect() CacheDump[K, V] {
+	dump := CacheDump[K, V]{
+		Capacity: c.capacity,
+		Length:   c.LinkedList.Length,
+		Entries:  make([]InspectEntry[K, V], 0, c.LinkedList.Length),
+	}
+
+	now := c.now()
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		entry := InspectEntry[K, V]{
+			Key:            node.Key,
+			Value:          node.Value,
+			AccessCount:    node.AccessCount,
+			CreatedAt:      node.CreatedAt,
+			LastAccessedAt: node.LastAccessedAt,
+			TTL:            -1,
+			IsExpired:      node.expired(now),
+		}
+		if deadline := node.deadline(); !deadline.IsZero() {
+			entry.TTL = max(deadline.Sub(now), 0)
+		}
+		dump.Entries = append(dump.Entries, entry)
+	}
+	return dump
+}
+
+// String formats the dump as a table with a row per entry.
+func (d CacheDump[K, V]) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "capacity %d, length %d\n", d.Capacity, d.Length)
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tACCESSES\tCREATED\tLAST ACCESSED\tTTL\tEXPIRED")
+	for _, e := range d.Entries {
+		ttl := "never"
+		if e.TTL >= 0 {
+			ttl = e.TTL.String()
+		}
+		fmt.Fprintf(w, "%v\t%v\t%d\t%s\t%s\t%s\t%t\n", e.Key, e.Value, e.AccessCount,
+			e.CreatedAt.Format(time.RFC3339), e.LastAccessedAt.Format(time.RFC3339), ttl, e.IsExpired)
+	}
+	w.Flush()
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (s *SyncCache[K, V]) Inspect() CacheDump[K, V] {
+	return s.cache.Inspect()
+}
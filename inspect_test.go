@@ -0,0 +1,51 @@
+package lru
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInspect(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	cache.SetWithTTL("Cat", 2, time.Second)
+	cache.SetWithTTL("Soda", 3, time.Minute)
+	clock.Advance(2 * time.Second)
+	cache.Peek("Dog")
+	cache.Check("Dog")
+
+	dump := cache.Inspect()
+	if dump.Capacity != 3 || dump.Length != 3 || len(dump.Entries) != 3 {
+		t.Fatalf("Unexpected dump: %+v", dump)
+	}
+
+	dog, cat, soda := dump.Entries[0], dump.Entries[2], dump.Entries[1]
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if dog.Key != "Dog" || dog.AccessCount != 1 || dog.TTL != -1 || dog.IsExpired ||
+		!dog.CreatedAt.Equal(start) || !dog.LastAccessedAt.Equal(start.Add(2*time.Second)) {
+		t.Errorf("Unexpected Dog entry: %+v", dog)
+	}
+	if cat.Key != "Cat" || !cat.IsExpired || cat.TTL != 0 {
+		t.Errorf("Expected Cat to be reported as expired, but got: %+v", cat)
+	}
+	if soda.Key != "Soda" || soda.IsExpired || soda.TTL != 58*time.Second {
+		t.Errorf("Expected Soda to have 58s left, but got: %+v", soda)
+	}
+
+	// Inspect neither reorders nor removes entries.
+	if cache.Len() != 3 {
+		t.Errorf("Expected the expired entry to be kept, but got Len %d", cache.Len())
+	}
+
+	lines := strings.Split(fmt.Sprint(dump), "\n")
+	if len(lines) != 5 || lines[0] != "capacity 3, length 3" || !strings.HasPrefix(lines[1], "KEY") {
+		t.Fatalf("Unexpected table: %q", lines)
+	}
+	if fields := strings.Fields(lines[2]); strings.Join(fields, " ") != "Dog 1 1 2024-01-01T00:00:00Z 2024-01-01T00:00:02Z never false" {
+		t.Errorf("Unexpected Dog row: %q", lines[2])
+	}
+}
@@ -0,0 +1,41 @@
+package lru
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// cacheJSON is the JSON form of a Cache.
+type cacheJSON[K comparable, V any] struct {
+	Capacity int           `json:"capacity"`
+	Entries  []Entry[K, V] `json:"entries"`
+}
+
+// MarshalJSON encodes the capacity of the cache and its entries, ordered from
+// the most to the least recently used one, so the cache can be restored with
+// UnmarshalJSON after a restart. Expiration times, costs and statistics are
+// not included.
+func (c *Cache[K, V]) MarshalJSON() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return json.Marshal(cacheJSON[K, V]{Capacity: c.capacity, Entries: c.entries()})
+}
+
+// UnmarshalJSON replaces the contents of the cache with the entries encoded
+// by MarshalJSON, keeping their recently used order, and takes over the
+// encoded capacity. It can be used on a zero Cache, which then behaves like
+// one created by New without options. Write-through functions are not called
+// for the restored entries.
+func (c *Cache[K, V]) UnmarshalJSON(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var decoded cacheJSON[K, V]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	slices.Reverse(decoded.Entries)
+	return c.restore(decoded.Capacity, decoded.Entries)
+}
@@ -0,0 +1,74 @@
+package lru
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	cache := New[string, int](testCacheSize)
+	for i, key := range []string{"Dog", "Cat", "Soda", "Tee", "Car"} {
+		cache.Set(key, i)
+	}
+	cache.Get("Cat")
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedJSON := `{"capacity":5,"entries":[{"key":"Cat","value":1},{"key":"Car","value":4},` +
+		`{"key":"Tee","value":3},{"key":"Soda","value":2},{"key":"Dog","value":0}]}`
+	if string(data) != expectedJSON {
+		t.Errorf("Expected JSON: %s, but got: %s", expectedJSON, data)
+	}
+
+	var restored Cache[string, int]
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalSlice(cache.Keys(), restored.Keys()) || !equalSlice(cache.Values(), restored.Values()) {
+		t.Errorf("Expected restored entries: %v, but got: %v", cache.Entries(), restored.Entries())
+	}
+	if restored.Cap() != testCacheSize {
+		t.Errorf("Expected restored capacity %d, but got: %d", testCacheSize, restored.Cap())
+	}
+
+	// The restored cache keeps working as an LRU cache.
+	restored.Set("Terry", 5)
+	if restored.Contains("Dog") || !restored.Contains("Cat") {
+		t.Errorf("Expected the least recently used entry to be evicted after restoring")
+	}
+}
+
+func TestUnmarshalJSONReplacesEntries(t *testing.T) {
+	cache := New[string, int](2)
+	cache.Set("Dog", 1)
+
+	data := []byte(`{"capacity":3,"entries":[{"key":"Cat","value":2},{"key":"Soda","value":3}]}`)
+	if err := json.Unmarshal(data, cache); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCacheState := []string{"Cat", "Soda"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if cache.Cap() != 3 {
+		t.Errorf("Expected capacity 3, but got: %d", cache.Cap())
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	var cache Cache[string, int]
+
+	if err := json.Unmarshal([]byte(`{"capacity":0,"entries":[]}`), &cache); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("Expected ErrInvalidCapacity, but got: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"capacity":"big"}`), &cache); err == nil {
+		t.Errorf("Expected an error for malformed JSON")
+	}
+}
@@ -0,0 +1,201 @@
+package lru
+
+import "fmt"
+
+// Policy is an eviction policy implemented outside the package, set with
+// WithPolicy. It only sees keys: the cache calls OnInsert when a key is added,
+// OnAccess when it is read or overwritten, OnDelete when it leaves the cache
+// for any reason, and Evict when the cache is full and an entry has to go.
+// Evict is only called while the policy tracks at least one key, and the key
+// it returns is then passed to OnDelete. Reset forgets every key, when the
+// cache is cleared or stops using the policy. A Policy is used by one cache at a
+// time and called with the cache's lock held, so it needs no locking of its
+// own.
+//
+// NewLRUPolicy, NewLFUPolicy, NewFIFOPolicy and NewClockPolicy implement the
+// basic policies this way, on top of the same code as their EvictionPolicy
+// counterparts. Evict must return a key the policy was given through OnInsert
+// and not through OnDelete since; the cache panics otherwise.
+type Policy[K comparable] interface {
+	OnAccess(key K)
+	Evict() K
+	OnInsert(key K)
+	OnDelete(key K)
+	Reset()
+}
+
+// WithPolicy makes the cache evict the entries chosen by p instead of using an
+// EvictionPolicy. The cache list keeps its most recently used order, so Keys,
+// Oldest and friends report recency whatever p does. p is not copied, so it
+// must not be shared with other caches: clones and the shards of a
+// ShardedCache fall back to the EvictionPolicy set with WithEvictionPolicy.
+func WithPolicy[K comparable, V any](p Policy[K]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.customPolicy = p
+	}
+}
+
+// customPolicy adapts a Policy to the nodes of a cache.
+type customPolicy[K comparable, V any] struct {
+	policy Policy[K]
+	cache  *Cache[K, V]
+}
+
+func (p *customPolicy[K, V]) add(node *Node[K, V]) {
+	p.policy.OnInsert(node.Key)
+}
+
+func (p *customPolicy[K, V]) access(node *Node[K, V]) bool {
+	p.policy.OnAccess(node.Key)
+	return true
+}
+
+func (p *customPolicy[K, V]) remove(node *Node[K, V]) {
+	p.policy.OnDelete(node.Key)
+}
+
+// victim returns the node of the key chosen by the policy. A policy picking a
+// key which is not cached, or the key being inserted, has lost track of the
+// cache; victim panics rather than evict another entry the policy would keep
+// counting as cached.
+func (p *customPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	key := p.policy.Evict()
+	node, ok := p.cache.Hash[key]
+	if !ok || node == incoming {
+		panic(fmt.Sprintf("lru: Policy.Evict returned %v, which is not a cached key", key))
+	}
+	return node
+}
+
+func (p *customPolicy[K, V]) clear() {
+	p.policy.Reset()
+}
+
+// SetPolicy switches the cache to evict the entries chosen by p, or by the
+// EvictionPolicy set with WithEvictionPolicy when p is nil, without losing
+// any entry. The previous policy is reset, then p is given every cached key
+// through OnInsert, from the least to the most recently used one, so it
+// starts out as if the keys had just been inserted in that order.
+func (c *Cache[K, V]) SetPolicy(p Policy[K]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.policy.clear()
+	c.customPolicy = p
+	c.policy = newPolicy(c.evictionPolicy, c)
+
+	for node := c.LinkedList.Tail.Left; node != c.LinkedList.Head; node = node.Left {
+		c.policy.add(node)
+	}
+}
+
+// SetPolicy behaves like Cache.SetPolicy.
+func (s *SyncCache[K, V]) SetPolicy(p Policy[K]) {
+	s.cache.SetPolicy(p)
+}
+
+// keyPolicy runs a built-in policy over keys alone, so the Policy types of
+// the package share the implementation of their EvictionPolicy counterparts.
+// It keeps its own list of nodes, ordered like the list of a cache.
+type keyPolicy[K comparable] struct {
+	list   LinkedList[K, struct{}]
+	nodes  map[K]*Node[K, struct{}]
+	policy policy[K, struct{}]
+}
+
+func (p *keyPolicy[K]) init(policy policy[K, struct{}]) {
+	p.list = createLinkedList[K, struct{}]()
+	p.nodes = map[K]*Node[K, struct{}]{}
+	p.policy = policy
+}
+
+func (p *keyPolicy[K]) OnInsert(key K) {
+	node := &Node[K, struct{}]{Key: key}
+	p.nodes[key] = node
+	p.list.pushFront(node)
+	p.policy.add(node)
+}
+
+func (p *keyPolicy[K]) OnAccess(key K) {
+	if node, ok := p.nodes[key]; ok && p.policy.access(node) {
+		p.list.moveToFront(node)
+	}
+}
+
+func (p *keyPolicy[K]) OnDelete(key K) {
+	if node, ok := p.nodes[key]; ok {
+		p.list.unlink(node)
+		delete(p.nodes, key)
+		p.policy.remove(node)
+	}
+}
+
+func (p *keyPolicy[K]) Evict() K {
+	return p.policy.victim(nil).Key
+}
+
+func (p *keyPolicy[K]) Reset() {
+	p.policy.clear()
+	p.list = createLinkedList[K, struct{}]()
+	clear(p.nodes)
+}
+
+// KeyLRUPolicy is the Policy returned by NewLRUPolicy.
+type KeyLRUPolicy[K comparable] struct {
+	keyPolicy[K]
+}
+
+// NewLRUPolicy returns a Policy evicting the least recently used key, like
+// PolicyLRU.
+func NewLRUPolicy[K comparable]() *KeyLRUPolicy[K] {
+	p := &KeyLRUPolicy[K]{}
+	p.init(&lruPolicy[K, struct{}]{list: &p.list})
+	return p
+}
+
+func (p *KeyLRUPolicy[K]) String() string { return "LRU" }
+
+// KeyFIFOPolicy is the Policy returned by NewFIFOPolicy.
+type KeyFIFOPolicy[K comparable] struct {
+	keyPolicy[K]
+}
+
+// NewFIFOPolicy returns a Policy evicting the key inserted first, like
+// PolicyFIFO. Accesses do not change the order.
+func NewFIFOPolicy[K comparable]() *KeyFIFOPolicy[K] {
+	p := &KeyFIFOPolicy[K]{}
+	p.init(&fifoPolicy[K, struct{}]{list: &p.list})
+	return p
+}
+
+func (p *KeyFIFOPolicy[K]) String() string { return "FIFO" }
+
+// KeyClockPolicy is the Policy returned by NewClockPolicy.
+type KeyClockPolicy[K comparable] struct {
+	keyPolicy[K]
+}
+
+// NewClockPolicy returns a Policy approximating LRU with the CLOCK algorithm,
+// like PolicyClock.
+func NewClockPolicy[K comparable]() *KeyClockPolicy[K] {
+	p := &KeyClockPolicy[K]{}
+	p.init(&clockPolicy[K, struct{}]{list: &p.list})
+	return p
+}
+
+func (p *KeyClockPolicy[K]) String() string { return "CLOCK" }
+
+// KeyLFUPolicy is the Policy returned by NewLFUPolicy.
+type KeyLFUPolicy[K comparable] struct {
+	keyPolicy[K]
+}
+
+// NewLFUPolicy returns a Policy evicting the least frequently used key, and
+// the least recently used one among keys used equally often, like PolicyLFU.
+func NewLFUPolicy[K comparable]() *KeyLFUPolicy[K] {
+	p := &KeyLFUPolicy[K]{}
+	p.init(newLFUPolicy[K, struct{}]())
+	return p
+}
+
+func (p *KeyLFUPolicy[K]) String() string { return "LFU" }
@@ -0,0 +1,150 @@
+package lru
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// mruPolicy evicts the most recently inserted or used key, a policy the
+// package does not provide.
+type mruPolicy struct {
+	keys []string
+}
+
+func (p *mruPolicy) OnInsert(key string) { p.keys = append(p.keys, key) }
+func (p *mruPolicy) Evict() string       { return p.keys[len(p.keys)-1] }
+
+func (p *mruPolicy) OnAccess(key string) {
+	p.OnDelete(key)
+	p.OnInsert(key)
+}
+
+func (p *mruPolicy) Reset() { p.keys = nil }
+
+func (p *mruPolicy) OnDelete(key string) {
+	p.keys = slices.DeleteFunc(p.keys, func(k string) bool { return k == key })
+}
+
+func TestWithPolicy(t *testing.T) {
+	cache := New(2, WithPolicy[string, int](&mruPolicy{}))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Get("Dog")
+	cache.Set("Soda", 3)
+
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"Soda", "Cat"}) {
+		t.Errorf("Expected the most recently used Dog to be evicted, but got: %v", keys)
+	}
+
+	cache.Clear()
+	cache.Set("Tee", 4)
+	cache.Set("Terry", 5)
+	cache.Set("Dog", 1)
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"Dog", "Tee"}) {
+		t.Errorf("Expected Terry to be evicted after Clear, but got: %v", keys)
+	}
+}
+
+// TestKeyPolicies replays a random workload against the Policy
+// implementations and their EvictionPolicy counterparts, which must keep the
+// same entries.
+func TestKeyPolicies(t *testing.T) {
+	tests := []struct {
+		policy Policy[int]
+		evict  EvictionPolicy
+	}{
+		{NewLRUPolicy[int](), PolicyLRU},
+		{NewLFUPolicy[int](), PolicyLFU},
+		{NewFIFOPolicy[int](), PolicyFIFO},
+		{NewClockPolicy[int](), PolicyClock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.evict.String(), func(t *testing.T) {
+			custom := New(8, WithPolicy[int, int](tt.policy))
+			builtin := New(8, WithEvictionPolicy[int, int](tt.evict))
+
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 5000; i++ {
+				key := rng.Intn(16)
+				switch n := rng.Intn(100); {
+				case n < 50:
+					custom.Get(key)
+					builtin.Get(key)
+				case n < 95:
+					custom.Set(key, i)
+					builtin.Set(key, i)
+				case n < 99:
+					custom.Delete(key)
+					builtin.Delete(key)
+				default:
+					custom.Clear()
+					builtin.Clear()
+				}
+
+				got, want := custom.Keys(), builtin.Keys()
+				slices.Sort(got)
+				slices.Sort(want)
+				if !slices.Equal(got, want) {
+					t.Fatalf("Step %d: expected keys %v, but got: %v", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSetPolicy(t *testing.T) {
+	lru := NewLRUPolicy[string]()
+	cache := NewSync(3, WithPolicy[string, int](lru))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+	cache.Get("Dog")
+	cache.Get("Dog")
+	cache.Set("Tee", 4)
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"Tee", "Dog", "Soda"}) {
+		t.Fatalf("Expected the least recently used Cat to be evicted, but got: %v", keys)
+	}
+
+	cache.SetPolicy(NewLFUPolicy[string]())
+	if len(lru.nodes) != 0 {
+		t.Errorf("Expected the previous policy to be reset, but it tracks %d keys", len(lru.nodes))
+	}
+
+	// Soda is the least recently used entry, but Tee is now the least
+	// frequently used one.
+	cache.Get("Dog")
+	cache.Get("Soda")
+	cache.Set("Terry", 5)
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"Terry", "Soda", "Dog"}) {
+		t.Errorf("Expected Tee to be evicted under LFU, but got: %v", keys)
+	}
+
+	// Without a Policy the EvictionPolicy, LRU by default, takes over.
+	cache.SetPolicy(nil)
+	cache.Set("Cat", 2)
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"Cat", "Terry", "Soda"}) {
+		t.Errorf("Expected Dog to be evicted under LRU, but got: %v", keys)
+	}
+}
+
+// forgetfulPolicy evicts a key it was never given.
+type forgetfulPolicy struct{ mruPolicy }
+
+func (p *forgetfulPolicy) Evict() string { return "Ghost" }
+
+func TestWithPolicyUnknownVictim(t *testing.T) {
+	cache := New(1, WithPolicy[string, int](&forgetfulPolicy{}))
+	cache.Set("Dog", 1)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic when the policy evicts a key which is not cached")
+		}
+	}()
+	cache.Set("Cat", 2)
+}
@@ -0,0 +1,85 @@
+package lru
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// load calls the configured loader for key and passes the result to store.
+// Concurrent loads of the same key are collapsed into one call, which runs
+// with the values of the context of the caller that started it but not its
+// cancellation, so one caller giving up does not fail the others. Every
+// caller stops waiting as soon as its own ctx is done. The result is stored
+// once, by the first caller to receive it, on that caller's goroutine, so
+// store never runs after its caller has returned.
+func (c *Cache[K, V]) load(ctx context.Context, key K, store func(key K, value V, err error)) (V, error) {
+	var zero V
+	results := c.loads.DoChan(flightKey(key), func() (any, error) {
+		value, err := c.callLoader(context.WithoutCancel(ctx), key)
+		return &loaded[V]{value: value, err: err}, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case result := <-results:
+		l := result.Val.(*loaded[V])
+		l.once.Do(func() { store(key, l.value, l.err) })
+		if l.err != nil {
+			c.log(slog.LevelWarn, "lru: loading entry failed", "key", key, "error", l.err)
+			return zero, l.err
+		}
+		return l.value, nil
+	}
+}
+
+// loaded is the result of a load shared by the callers waiting for it.
+type loaded[V any] struct {
+	value V
+	err   error
+	once  sync.Once
+}
+
+// flightKey turns key into the string singleflight deduplicates calls by.
+func flightKey[K comparable](key K) string {
+	if s, ok := any(key).(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%#v", key)
+}
+
+// store caches a value which came from the backing store, so it is not
+// written back to it.
+func (c *Cache[K, V]) store(key K, value V) {
+	c.set(key, value, c.defaultTTL, 0, 1)
+}
+
+// write persists value through the write-through function, if any, and only
+// caches it once that succeeded.
+func (c *Cache[K, V]) write(ctx context.Context, key K, value V, ttl, stale time.Duration, cost int) error {
+	c.recordOp(opSet, key)
+	if c.stats.maxBytes > 0 && c.sizeOf(key, value) > c.stats.maxBytes {
+		return ErrEntryTooLarge
+	}
+	if err := c.persist(ctx, key, value); err != nil {
+		return err
+	}
+
+	c.set(key, value, ttl, stale, cost)
+	return nil
+}
+
+func (c *Cache[K, V]) persist(ctx context.Context, key K, value V) error {
+	if c.writer == nil {
+		return nil
+	}
+
+	if err := c.writer(ctx, key, value); err != nil {
+		c.log(slog.LevelWarn, "lru: writing entry through failed", "key", key, "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package lru
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithLoader(t *testing.T) {
+	calls := 0
+	cache := New(2, WithLoader(func(key string) (int, error) {
+		calls++
+		if key == "Cat" {
+			return 0, errors.New("not found")
+		}
+		return len(key), nil
+	}))
+
+	if value, found := cache.Get("Dog"); !found || value != 3 {
+		t.Errorf("Expected loaded (3, true), but got: (%d, %t)", value, found)
+	}
+	if value, found := cache.Get("Dog"); !found || value != 3 {
+		t.Errorf("Expected cached (3, true), but got: (%d, %t)", value, found)
+	}
+	if calls != 1 {
+		t.Errorf("Expected loader to be called once, but got: %d", calls)
+	}
+
+	if _, found := cache.Get("Cat"); found {
+		t.Errorf("Expected failed load to be reported as a miss")
+	}
+	if cache.Contains("Cat") {
+		t.Errorf("Expected failed load not to be cached")
+	}
+}
+
+func TestWithLoaderDeduplicatesConcurrentMisses(t *testing.T) {
+	const goroutines = 100
+
+	var calls atomic.Int32
+	var started sync.WaitGroup
+	started.Add(goroutines)
+
+	cache := NewSync(16, WithLoader(func(key string) (string, error) {
+		calls.Add(1)
+		// Hold the load open until every goroutine has missed.
+		started.Wait()
+		time.Sleep(10 * time.Millisecond)
+		return "Value of " + key, nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			if value, found := cache.Get("Dog"); !found || value != "Value of Dog" {
+				t.Errorf("Expected (Value of Dog, true), but got: (%q, %t)", value, found)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected loader to be called once, but got: %d", calls.Load())
+	}
+}
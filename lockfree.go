@@ -0,0 +1,222 @@
+package lru
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// LockFreeCache is a thread-safe cache built for read-heavy workloads. Get
+// never takes the writer mutex: it finds the entry in a sharded index under a
+// per-shard read lock, which writers only hold while they update the index,
+// and loads its value with an atomic pointer load.
+// Because reads cannot reorder a shared list, recency is approximated with
+// the CLOCK algorithm: a hit only marks the entry as referenced, and
+// eviction, which runs under the single writer mutex, sweeps the entries and
+// evicts the first one which was not referenced since the last sweep.
+type LockFreeCache[K comparable, V any] struct {
+	// mu serializes all writers. Readers never take it.
+	mu     sync.Mutex
+	shards []lockFreeShard[K, V]
+	mask   uint64
+
+	// slots is the clock ring. Free slots are nil and listed in free.
+	slots []*lockFreeEntry[K, V]
+	free  []int
+	hand  int
+	len   atomic.Int64
+}
+
+var _ Interface[string, string] = (*LockFreeCache[string, string])(nil)
+
+type lockFreeShard[K comparable, V any] struct {
+	mu      sync.RWMutex
+	entries map[K]*lockFreeEntry[K, V]
+}
+
+type lockFreeEntry[K comparable, V any] struct {
+	key        K
+	value      atomic.Pointer[V]
+	referenced atomic.Bool
+	slot       int
+}
+
+// NewLockFree creates an empty lock-free cache which holds at most capacity
+// entries.
+func NewLockFree[K comparable, V any](capacity int) (*LockFreeCache[K, V], error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	c := &LockFreeCache[K, V]{
+		shards: make([]lockFreeShard[K, V], DefaultShards),
+		mask:   DefaultShards - 1,
+		slots:  make([]*lockFreeEntry[K, V], capacity),
+		free:   make([]int, 0, capacity),
+	}
+	for i := range c.shards {
+		c.shards[i].entries = map[K]*lockFreeEntry[K, V]{}
+	}
+	for i := capacity - 1; i >= 0; i-- {
+		c.free = append(c.free, i)
+	}
+
+	return c, nil
+}
+
+func (c *LockFreeCache[K, V]) shard(key K) *lockFreeShard[K, V] {
+	return &c.shards[hashKey(key)&c.mask]
+}
+
+func (c *LockFreeCache[K, V]) lookup(key K) *lockFreeEntry[K, V] {
+	s := c.shard(key)
+	s.mu.RLock()
+	e := s.entries[key]
+	s.mu.RUnlock()
+	return e
+}
+
+// Get returns the value stored under key and marks the entry as referenced.
+func (c *LockFreeCache[K, V]) Get(key K) (V, bool) {
+	e := c.lookup(key)
+	if e == nil {
+		var zero V
+		return zero, false
+	}
+
+	// Only write the flag when it changes, so hot entries do not bounce
+	// their cache line between CPUs.
+	if !e.referenced.Load() {
+		e.referenced.Store(true)
+	}
+	return *e.value.Load(), true
+}
+
+// Set stores value under key. When the cache is full the CLOCK hand picks
+// the entry to evict. It never returns an error.
+func (c *LockFreeCache[K, V]) Set(key K, value V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Writers hold mu, so the index can be read without the shard lock.
+	s := c.shard(key)
+	if e, ok := s.entries[key]; ok {
+		e.value.Store(&value)
+		e.referenced.Store(true)
+		return nil
+	}
+
+	if len(c.free) == 0 {
+		c.evict()
+	}
+
+	slot := c.free[len(c.free)-1]
+	c.free = c.free[:len(c.free)-1]
+
+	e := &lockFreeEntry[K, V]{key: key, slot: slot}
+	e.value.Store(&value)
+	c.slots[slot] = e
+
+	s.mu.Lock()
+	s.entries[key] = e
+	s.mu.Unlock()
+
+	c.len.Add(1)
+	return nil
+}
+
+// evict sweeps the clock ring from the hand, clearing referenced marks, and
+// removes the first unreferenced entry. It must be called with mu held on a
+// full cache.
+func (c *LockFreeCache[K, V]) evict() {
+	for {
+		e := c.slots[c.hand]
+		c.hand = (c.hand + 1) % len(c.slots)
+
+		if e.referenced.Load() {
+			e.referenced.Store(false)
+			continue
+		}
+
+		c.remove(e)
+		return
+	}
+}
+
+// remove drops e from the index and frees its slot. It must be called with mu
+// held.
+func (c *LockFreeCache[K, V]) remove(e *lockFreeEntry[K, V]) {
+	s := c.shard(e.key)
+	s.mu.Lock()
+	delete(s.entries, e.key)
+	s.mu.Unlock()
+
+	c.slots[e.slot] = nil
+	c.free = append(c.free, e.slot)
+	c.len.Add(-1)
+}
+
+// Delete removes key from the cache. It reports whether the key was present.
+func (c *LockFreeCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.shard(key).entries[key]
+	if !ok {
+		return false
+	}
+
+	c.remove(e)
+	return true
+}
+
+// Peek returns the value stored under key without marking it as referenced.
+func (c *LockFreeCache[K, V]) Peek(key K) (V, bool) {
+	e := c.lookup(key)
+	if e == nil {
+		var zero V
+		return zero, false
+	}
+	return *e.value.Load(), true
+}
+
+// Contains reports whether key is cached, without marking it as referenced.
+func (c *LockFreeCache[K, V]) Contains(key K) bool {
+	return c.lookup(key) != nil
+}
+
+// Len returns the number of entries currently held by the cache.
+func (c *LockFreeCache[K, V]) Len() int {
+	return int(c.len.Load())
+}
+
+// Cap returns the maximum number of entries the cache can hold.
+func (c *LockFreeCache[K, V]) Cap() int {
+	return len(c.slots)
+}
+
+// Clear removes all entries from the cache.
+func (c *LockFreeCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.slots {
+		if e != nil {
+			c.remove(e)
+		}
+	}
+	c.hand = 0
+}
+
+// ForEach calls fn for every entry in no particular order, without marking
+// them as referenced. Iteration stops early when fn returns false. The writer
+// mutex is held for the whole iteration, so fn must not modify the cache.
+func (c *LockFreeCache[K, V]) ForEach(fn func(key K, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.slots {
+		if e != nil && !fn(e.key, *e.value.Load()) {
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package lru
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+// BenchmarkReadHeavy compares LockFreeCache with the mutex guarded SyncCache
+// under 16 concurrent readers. The readers are spread over GOMAXPROCS, so
+// run it with -cpu 1,4,16 to see how both scale with the number of CPUs.
+func BenchmarkReadHeavy(b *testing.B) {
+	keys := make([]string, benchCapacity)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("Element%d", i)
+	}
+
+	const readers = 16
+	b.Run("mutex", func(b *testing.B) {
+		cache := NewSync[string, int](benchCapacity)
+		benchmarkReadHeavy(b, cache, keys, readers)
+	})
+	b.Run("lockfree", func(b *testing.B) {
+		cache, err := NewLockFree[string, int](benchCapacity)
+		if err != nil {
+			b.Fatal(err)
+		}
+		benchmarkReadHeavy(b, cache, keys, readers)
+	})
+}
+
+// benchmarkReadHeavy fills the cache and runs b.N operations, 95% Get and 5%
+// Set, from about the given number of goroutines. b.SetParallelism starts
+// that many per GOMAXPROCS, so it is divided by GOMAXPROCS first.
+func benchmarkReadHeavy(b *testing.B, cache Interface[string, int], keys []string, goroutines int) {
+	for i, key := range keys {
+		cache.Set(key, i)
+	}
+
+	var next atomic.Int64
+	b.SetParallelism(max(1, goroutines/runtime.GOMAXPROCS(0)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		// Each goroutine starts at its own offset in keys.
+		i := int(next.Add(1)) * len(keys) / goroutines
+		for ; pb.Next(); i++ {
+			key := keys[i%len(keys)]
+			if i%20 == 0 {
+				cache.Set(key, i)
+			} else {
+				cache.Get(key)
+			}
+		}
+	})
+}
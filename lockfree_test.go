@@ -0,0 +1,98 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestLockFreeCache(t *testing.T) {
+	cache, err := NewLockFree[string, int](3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+
+	if value, found := cache.Get("Dog"); !found || value != 1 {
+		t.Errorf("Expected (1, true), but got: (%d, %t)", value, found)
+	}
+
+	// Dog is referenced, so the clock hand passes it and evicts Cat.
+	cache.Set("Tee", 4)
+	if cache.Contains("Cat") || !cache.Contains("Dog") {
+		t.Errorf("Expected Cat to be evicted and Dog to survive")
+	}
+
+	cache.Set("Dog", 10)
+	if value, _ := cache.Peek("Dog"); value != 10 {
+		t.Errorf("Expected updated value 10, but got: %d", value)
+	}
+
+	if !cache.Delete("Soda") || cache.Delete("Soda") {
+		t.Errorf("Expected Delete to report the key once")
+	}
+	if cache.Len() != 2 || cache.Cap() != 3 {
+		t.Errorf("Expected Len 2 and Cap 3, but got: %d and %d", cache.Len(), cache.Cap())
+	}
+
+	// The freed slot is reused without evicting.
+	cache.Set("Car", 5)
+	if cache.Len() != 3 || !cache.Contains("Dog") || !cache.Contains("Tee") {
+		t.Errorf("Expected the deleted slot to be reused")
+	}
+
+	seen := map[string]int{}
+	cache.ForEach(func(key string, value int) bool {
+		seen[key] = value
+		return true
+	})
+	if len(seen) != 3 || seen["Car"] != 5 {
+		t.Errorf("Expected ForEach to visit all entries, but got: %v", seen)
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 || cache.Contains("Dog") {
+		t.Errorf("Expected Clear to empty the cache")
+	}
+}
+
+func TestNewLockFreeInvalidCapacity(t *testing.T) {
+	if _, err := NewLockFree[string, int](0); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("Expected ErrInvalidCapacity, but got: %v", err)
+	}
+}
+
+func TestLockFreeCacheConcurrent(t *testing.T) {
+	cache, err := NewLockFree[string, int](64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := fmt.Sprintf("Element%d", (g*1000+i)%100)
+				switch i % 10 {
+				case 0:
+					cache.Set(key, i)
+				case 1:
+					cache.Delete(key)
+				default:
+					cache.Get(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Expected Len to stay within Cap, but got: %d", cache.Len())
+	}
+}
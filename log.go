@@ -0,0 +1,45 @@
+package lru
+
+import (
+	"context"
+	"log/slog"
+)
+
+// removalReason tells why an entry left the cache, for logging.
+type removalReason string
+
+const (
+	removedForCapacity removalReason = "capacity"
+	removedExpired     removalReason = "ttl"
+	removedExplicitly  removalReason = "explicit"
+)
+
+// log writes a record to the configured logger, if any.
+func (c *Cache[K, V]) log(level slog.Level, msg string, args ...any) {
+	if c.logger == nil || !c.logger.Enabled(context.Background(), level) {
+		return
+	}
+	c.logger.Log(context.Background(), level, msg, args...)
+}
+
+// remove drops node from the cache and logs why it was dropped.
+func (c *Cache[K, V]) remove(node *Node[K, V], reason removalReason) {
+	c.log(slog.LevelDebug, "lru: entry removed", "key", node.Key, "reason", reason)
+	key, value := node.Key, node.Value
+	c.unlink(node)
+	c.releaseNode(node)
+
+	var zero V
+	if reason == removedExplicitly {
+		c.notifyChange(ChangeDelete, key, value, zero)
+	} else {
+		c.notifyChange(ChangeEvict, key, value, zero)
+	}
+
+	if reason == removedExpired && c.expired != nil {
+		select {
+		case c.expired <- key:
+		default:
+		}
+	}
+}
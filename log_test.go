@@ -0,0 +1,71 @@
+package lru
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	cache := New(2, WithLogger[string, int](newTestLogger(&buf)))
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+	cache.Delete("Cat")
+	cache.SetWithTTL("Tee", 4, time.Second)
+	clock.Advance(2 * time.Second)
+	cache.Get("Tee")
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	if err := cache.Resize(1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cache.GetOrSet("Terry", func() (int, error) {
+		return 0, errors.New("database is down")
+	})
+
+	expected := []string{
+		`level=DEBUG msg="lru: entry removed" key=Dog reason=capacity`,
+		`level=DEBUG msg="lru: entry removed" key=Cat reason=explicit`,
+		`level=DEBUG msg="lru: entry removed" key=Tee reason=ttl`,
+		`level=DEBUG msg="lru: entry removed" key=Soda reason=capacity`,
+		`level=DEBUG msg="lru: entry removed" key=Dog reason=capacity`,
+		`level=INFO msg="lru: cache resized" old_capacity=2 new_capacity=1 evicted=1`,
+		`level=WARN msg="lru: loading entry failed" key=Terry error="database is down"`,
+	}
+	actual := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !equalSlice(expected, actual) {
+		t.Errorf("Expected log lines:\n%s\nbut got:\n%s", strings.Join(expected, "\n"), buf.String())
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	cache := New(1, WithLogger[string, int](logger))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug records to be filtered out, but got: %s", buf.String())
+	}
+}
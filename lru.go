@@ -0,0 +1,844 @@
+// Package lru implements a fixed size LRU (Least Recently Used) cache backed
+// by a doubly linked list and a hash map.
+package lru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+)
+
+var (
+	// ErrInvalidCapacity is returned when a cache is given a capacity that
+	// is not positive.
+	ErrInvalidCapacity = errors.New("lru: capacity must be positive")
+
+	// ErrNotFound is returned by Fetch when the key is not cached.
+	ErrNotFound = errors.New("lru: key not found")
+
+	// ErrExpired is returned by Fetch when the entry stored under the key has
+	// expired. The entry is removed.
+	ErrExpired = errors.New("lru: entry expired")
+
+	// ErrCacheFull is matched by the errors returned when an entry can never
+	// fit in the cache, ErrCostTooHigh and ErrEntryTooLarge.
+	ErrCacheFull = errors.New("lru: cache is full")
+)
+
+// Interface is the set of operations shared by cache implementations, so
+// callers can depend on it instead of a concrete type.
+type Interface[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V) error
+	Delete(key K) bool
+	Peek(key K) (V, bool)
+	Contains(key K) bool
+	Len() int
+	Cap() int
+	Clear()
+	ForEach(fn func(key K, value V) bool)
+}
+
+var _ Interface[string, string] = (*Cache[string, string])(nil)
+
+// Cache is an LRU cache holding at most a fixed number of entries. Its methods
+// are safe for concurrent use as they are guarded by an internal mutex;
+// LinkedList and Hash are not guarded by it, and callbacks such as the
+// WithOnEvict hook or the ForEach function must not call back into the cache.
+type Cache[K comparable, V any] struct {
+	LinkedList LinkedList[K, V]
+	Hash       Hash[K, V]
+
+	// mu guards every exported method. Get and Check relink the list, so
+	// they take the write lock like every other update; read-only methods
+	// share the read lock.
+	mu sync.RWMutex
+
+	capacity       int
+	totalCost      int
+	evictionPolicy EvictionPolicy
+	policy         policy[K, V]
+	customPolicy   Policy[K]
+	now            func() time.Time
+	defaultTTL     time.Duration
+	slidingTTL     bool
+	onEvict        func(key K, value V)
+	onFlush        func(key K, value V) error
+	onHit          func(key K, value V)
+	onMiss         func(key K, _ struct{}) // an empty value, to chain through chainHooks
+	expired        chan<- K
+	stats          stats
+	logger         *slog.Logger
+	loader         func(ctx context.Context, key K) (V, error)
+	writer         func(ctx context.Context, key K, value V) error
+	limiter        *rate.Limiter
+	breaker        *breaker
+	negativeTTL    time.Duration
+	notFound       []error
+	bloom          *bloomFilter
+	ghost          *GhostCache[K]
+	recorder       *recorder
+	workingSet     *WorkingSetEstimator[K]
+	changes        chan ChangeEvent[K, V]
+	changeBuffer   int
+
+	// watchers holds the channels returned by WatchKey. It has its own mutex
+	// as watchers are removed when their context is done. watching counts
+	// them so changes are not slowed down by the mutex while nobody watches.
+	watchMu  sync.Mutex
+	watchers map[K][]chan V
+	watching atomic.Int64
+
+	// tags maps every tag to the keys of the entries carrying it.
+	tags map[string]map[K]struct{}
+
+	refreshMu    sync.Mutex
+	refreshed    []refresh[K, V]
+	hasRefreshed atomic.Bool
+	loads        singleflight.Group
+
+	// sets collapses the loader calls of concurrent GetOrSet misses.
+	sets singleflight.Group
+
+	// nodes recycles the nodes of removed entries, unless noPool is set,
+	// which only benchmarks do.
+	nodes  sync.Pool
+	noPool bool
+}
+
+func (c *Cache[K, V]) Add(node *Node[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.add(node)
+}
+
+// add is Add without locking.
+func (c *Cache[K, V]) add(node *Node[K, V]) {
+	/* If the cache is full, we first drop the element chosen by the eviction
+	policy, which for LRU is the least accessed element, so we consider this as
+	one of cache invalidation rules. Doing it before linking the new node makes
+	sure the new node itself is never chosen. */
+	if node.CreatedAt.IsZero() {
+		node.CreatedAt = c.now()
+		node.LastAccessedAt = node.CreatedAt
+	}
+	node.size = c.sizeOf(node.Key, node.Value)
+	for c.LinkedList.Length > 0 && (c.totalCost+node.cost > c.capacity ||
+		(c.stats.maxBytes > 0 && c.stats.bytes.Load()+node.size > c.stats.maxBytes)) {
+		c.evict(node)
+	}
+
+	c.LinkedList.pushFront(node)
+	c.totalCost += node.cost
+	c.stats.bytes.Add(node.size)
+	c.policy.add(node)
+	if c.bloom != nil {
+		c.bloom.add(hashKey(node.Key))
+	}
+}
+
+func (c *Cache[K, V]) Remove(node *Node[K, V]) *Node[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.unlink(node)
+}
+
+// unlink is Remove without locking.
+func (c *Cache[K, V]) unlink(node *Node[K, V]) *Node[K, V] {
+	// Let the eviction callback see the entry while it is still cached.
+	if c.onEvict != nil {
+		c.onEvict(node.Key, node.Value)
+	}
+
+	c.LinkedList.unlink(node)
+	delete(c.Hash, node.Key)
+	c.totalCost -= node.cost
+	c.stats.bytes.Add(-node.size)
+	c.policy.remove(node)
+	c.untag(node)
+	if c.bloom != nil {
+		c.bloom.remove(hashKey(node.Key))
+	}
+
+	return node
+}
+
+// Get returns the value stored under key and marks the entry as the most
+// recently used one. The boolean reports whether the key was found. When a
+// loader is configured with WithLoader, a miss is filled by calling it.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	value, err := c.lockedFetch(key)
+	if !c.shouldLoad(err) {
+		return value, err == nil
+	}
+
+	value, err = c.load(context.Background(), key, c.lockedStoreLoaded)
+	return value, err == nil
+}
+
+// Fetch is Get reporting why a key was not found: ErrNotFound for a key which
+// is not cached and ErrExpired for an expired entry. When a loader is
+// configured with WithLoader, a miss is filled by calling it instead, and a
+// failed load returns the loader error.
+func (c *Cache[K, V]) Fetch(key K) (V, error) {
+	value, err := c.lockedFetch(key)
+	if !c.shouldLoad(err) {
+		return value, err
+	}
+
+	return c.load(context.Background(), key, c.lockedStoreLoaded)
+}
+
+// lockedFetch is fetch holding the lock, which is released before a miss is
+// loaded so other keys can be served meanwhile.
+func (c *Cache[K, V]) lockedFetch(key K) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.fetch(key)
+}
+
+// get is Get without falling back to the configured loader.
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	value, err := c.fetch(key)
+	return value, err == nil
+}
+
+// fetch is Fetch without falling back to the configured loader.
+func (c *Cache[K, V]) fetch(key K) (V, error) {
+	c.recordOp(opGet, key)
+	if c.workingSet != nil {
+		c.workingSet.Observe(key)
+	}
+	node, err := c.find(key)
+	if err != nil {
+		c.stats.misses.Add(1)
+		if c.ghostHit(key) {
+			c.stats.ghostHits.Add(1)
+		}
+		if c.onMiss != nil {
+			c.onMiss(key, struct{}{})
+		}
+		var zero V
+		return zero, err
+	}
+
+	c.recordAccess(node)
+	c.promote(node)
+	c.touch(node)
+	c.revalidate(node)
+	c.stats.hits.Add(1)
+	if c.onHit != nil {
+		c.onHit(key, node.Value)
+	}
+	return node.Value, nil
+}
+
+// Set stores value under key, replacing any previous value, and marks the
+// entry as the most recently used one. The entry never expires, unless a
+// default lifetime is set with WithTTL. An error is
+// only returned when write-through persistence fails, in which case the cache
+// is left unchanged.
+func (c *Cache[K, V]) Set(key K, value V) error {
+	if err := waitWrite(context.Background(), c.limiter); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.write(context.Background(), key, value, c.defaultTTL, 0, 1)
+}
+
+// GetOrSet returns the value stored under key, promoting it like Get. On a
+// miss it calls loader and caches its result. Errors returned by loader are
+// passed through and nothing is cached. The lock is not held while loader
+// runs; concurrent callers missing the same key share a single call.
+func (c *Cache[K, V]) GetOrSet(key K, loader func() (V, error)) (V, error) {
+	if value, err := c.lockedFetch(key); err == nil {
+		return value, nil
+	}
+
+	result, err, _ := c.sets.Do(flightKey(key), func() (any, error) {
+		value, err := loader()
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.store(key, value)
+		return value, nil
+	})
+	if err != nil {
+		c.log(slog.LevelWarn, "lru: loading entry failed", "key", key, "error", err)
+		var zero V
+		return zero, err
+	}
+	return result.(V), nil
+}
+
+// Peek returns the value stored under key without updating its position in
+// the recently used order.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node, ok := c.Hash[key]
+	if !ok || !node.live(c.now()) {
+		var zero V
+		return zero, false
+	}
+
+	return node.Value, true
+}
+
+// Contains reports whether key is cached, without updating its position in
+// the recently used order.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node, ok := c.Hash[key]
+	return ok && node.live(c.now())
+}
+
+// Oldest returns the least recently used entry without updating its position.
+// The boolean is false when the cache is empty.
+func (c *Cache[K, V]) Oldest() (key K, value V, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.LinkedList.Length == 0 {
+		return key, value, false
+	}
+
+	node := c.LinkedList.Tail.Left
+	return node.Key, node.Value, true
+}
+
+// Newest returns the most recently used entry without updating its position.
+// The boolean is false when the cache is empty.
+func (c *Cache[K, V]) Newest() (key K, value V, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.LinkedList.Length == 0 {
+		return key, value, false
+	}
+
+	node := c.LinkedList.Head.Right
+	return node.Key, node.Value, true
+}
+
+// Keys returns all cached keys ordered from the most to the least recently
+// used one.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]K, 0, c.LinkedList.Length)
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		keys = append(keys, node.Key)
+	}
+	return keys
+}
+
+// Values returns all cached values ordered from the most to the least
+// recently used entry.
+func (c *Cache[K, V]) Values() []V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	values := make([]V, 0, c.LinkedList.Length)
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		values = append(values, node.Value)
+	}
+	return values
+}
+
+// Entries returns all cached key-value pairs ordered from the most to the
+// least recently used one.
+func (c *Cache[K, V]) Entries() []Entry[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.entries()
+}
+
+// entries is Entries without locking.
+func (c *Cache[K, V]) entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, c.LinkedList.Length)
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		entries = append(entries, Entry[K, V]{Key: node.Key, Value: node.Value})
+	}
+	return entries
+}
+
+// ToMap copies all cached entries into a new map.
+func (c *Cache[K, V]) ToMap() map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m := make(map[K]V, c.LinkedList.Length)
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		m[node.Key] = node.Value
+	}
+	return m
+}
+
+// FromMap stores every entry of m in the cache, in the iteration order of m.
+// When m holds more entries than the cache can, the entries stored first are
+// evicted again. Like values filled by a loader, the entries are not written
+// through.
+func (c *Cache[K, V]) FromMap(m map[K]V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, value := range m {
+		c.store(key, value)
+	}
+}
+
+// ForEach calls fn for every entry, from the most to the least recently used
+// one, without updating their positions. Iteration stops early when fn returns
+// false. fn must not modify the cache.
+func (c *Cache[K, V]) ForEach(fn func(key K, value V) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		if !fn(node.Key, node.Value) {
+			return
+		}
+	}
+}
+
+// Delete removes key from the cache. It reports whether the key was present.
+func (c *Cache[K, V]) Delete(key K) bool {
+	waitWrite(context.Background(), c.limiter)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.deleteKey(key)
+}
+
+// deleteKey is Delete without locking.
+func (c *Cache[K, V]) deleteKey(key K) bool {
+	c.recordOp(opDelete, key)
+
+	node, ok := c.Hash[key]
+	if !ok {
+		return false
+	}
+
+	c.remove(node, removedExplicitly)
+	return true
+}
+
+// Clear removes all entries from the cache, calling the eviction callback for
+// each of them from the least to the most recently used one.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clearAll()
+}
+
+// clearAll is Clear without locking.
+func (c *Cache[K, V]) clearAll() {
+	for node := c.LinkedList.Tail.Left; node != c.LinkedList.Head; node = node.Left {
+		c.log(slog.LevelDebug, "lru: entry removed", "key", node.Key, "reason", removedExplicitly)
+		if c.onEvict != nil {
+			c.onEvict(node.Key, node.Value)
+		}
+		var zero V
+		c.notifyChange(ChangeDelete, node.Key, node.Value, zero)
+	}
+
+	c.LinkedList.Head.Right = c.LinkedList.Tail
+	c.LinkedList.Tail.Left = c.LinkedList.Head
+	c.LinkedList.Length = 0
+	c.totalCost = 0
+	c.stats.bytes.Store(0)
+	clear(c.Hash)
+	clear(c.tags)
+	c.policy.clear()
+	if c.bloom != nil {
+		c.bloom.reset()
+	}
+}
+
+// Len returns the number of entries currently held by the cache.
+func (c *Cache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.LinkedList.Length
+}
+
+// Cap returns the maximum number of entries the cache can hold, or the maximum
+// total cost when entries are stored with SetWithCost.
+func (c *Cache[K, V]) Cap() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.capacity
+}
+
+// Resize changes the maximum number of entries, or total cost, the cache can
+// hold. When the cache no longer fits, entries are evicted until it does.
+func (c *Cache[K, V]) Resize(newCapacity int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if newCapacity <= 0 {
+		return ErrInvalidCapacity
+	}
+
+	oldCapacity, oldLength := c.capacity, c.LinkedList.Length
+	c.capacity = newCapacity
+	for c.overCapacity() {
+		c.evict(nil)
+	}
+
+	c.log(slog.LevelInfo, "lru: cache resized",
+		"old_capacity", oldCapacity, "new_capacity", newCapacity, "evicted", oldLength-c.LinkedList.Length)
+	return nil
+}
+
+// SetNX stores value under key only if the key is not cached yet. It reports
+// whether the value was stored; an existing entry is left untouched and keeps
+// its position. False is also returned when write-through persistence fails.
+func (c *Cache[K, V]) SetNX(key K, value V) bool {
+	if err := waitWrite(context.Background(), c.limiter); err != nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lookup(key); ok {
+		return false
+	}
+
+	return c.write(context.Background(), key, value, c.defaultTTL, 0, 1) == nil
+}
+
+// Swap replaces the value stored under key in place and returns the previous
+// one. The entry keeps its position in the recently used order. When the key is
+// not cached, value is inserted as the most recently used entry and the
+// boolean is false. When write-through persistence fails the cache is left
+// unchanged and the zero value and false are returned.
+func (c *Cache[K, V]) Swap(key K, value V) (V, bool) {
+	var zero V
+	if err := waitWrite(context.Background(), c.limiter); err != nil {
+		return zero, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.persist(context.Background(), key, value); err != nil {
+		return zero, false
+	}
+
+	if node, ok := c.lookup(key); ok {
+		old := node.Value
+		node.Value = value
+		c.reweigh(node, node.cost)
+		c.notifyChange(ChangeSet, key, old, value)
+		return old, true
+	}
+
+	c.set(key, value, c.defaultTTL, 0, 1)
+	return zero, false
+}
+
+// insert adds a node for a key which is not cached yet.
+func (c *Cache[K, V]) insert(node *Node[K, V]) {
+	c.Hash[node.Key] = node
+	c.stats.insertions.Add(1)
+	if c.ghost != nil {
+		c.ghost.Remove(node.Key)
+	}
+	c.add(node)
+
+	var zero V
+	c.notifyChange(ChangeSet, node.Key, zero, node.Value)
+}
+
+// evict drops the entry chosen by the eviction policy to make room for
+// incoming, which is nil when the cache shrinks.
+func (c *Cache[K, V]) evict(incoming *Node[K, V]) {
+	c.stats.evictions.Add(1)
+	victim := c.policy.victim(incoming)
+	if c.ghost != nil {
+		c.ghost.Add(victim.Key)
+	}
+	c.remove(victim, removedForCapacity)
+}
+
+// promote records an access to node with the eviction policy.
+func (c *Cache[K, V]) promote(node *Node[K, V]) {
+	if c.policy.access(node) {
+		c.moveToFront(node)
+	}
+}
+
+// moveToFront relinks an already cached node right after the head, without
+// touching the hash or the list length.
+func (c *Cache[K, V]) moveToFront(node *Node[K, V]) {
+	c.LinkedList.moveToFront(node)
+}
+
+func (c *Cache[K, V]) Check(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	/* Check if key is in the cache hash; If it is, then move it to the front
+	   as recently used value; If not create and also add to cache hash. */
+	if existingCacheValue, ok := c.lookup(key); ok {
+		c.recordAccess(existingCacheValue)
+		c.promote(existingCacheValue)
+		return
+	}
+
+	// A cached "not found" result is turned into a regular entry.
+	if _, ok := c.Hash[key]; ok {
+		var zero V
+		c.set(key, zero, 0, 0, 1)
+		return
+	}
+
+	node := c.newNode()
+	node.Key, node.cost = key, 1
+	c.insert(node)
+}
+
+// Display prints the state of every entry as a table, see Inspect.
+func (c *Cache[K, V]) Display() {
+	fmt.Println(c.Inspect())
+}
+
+// String formats the keys of the cache from the most to the least recently
+// used one.
+func (c *Cache[K, V]) String() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.LinkedList.String()
+}
+
+func (q *LinkedList[K, V]) Display() {
+	fmt.Println(q.String())
+}
+
+func (q *LinkedList[K, V]) String() string {
+	var b strings.Builder
+	node := q.Head.Right
+
+	fmt.Fprintf(&b, "%d - [", q.Length)
+	for i := 0; i < q.Length; i++ {
+		fmt.Fprintf(&b, "{%v}", node.Key)
+		if i < q.Length-1 {
+			b.WriteString("<-->")
+		}
+		node = node.Right
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+type LinkedList[K comparable, V any] struct {
+	Head   *Node[K, V]
+	Tail   *Node[K, V]
+	Length int
+}
+
+// pushFront links node right after the head.
+func (q *LinkedList[K, V]) pushFront(node *Node[K, V]) {
+	first := q.Head.Right
+	q.Head.Right = node
+	node.Left = q.Head
+	node.Right = first
+	first.Left = node
+	q.Length++
+}
+
+// unlink takes node out of the list. node keeps pointing at its former
+// neighbours, which the CLOCK policy relies on to move its hand.
+func (q *LinkedList[K, V]) unlink(node *Node[K, V]) {
+	node.Left.Right = node.Right
+	node.Right.Left = node.Left
+	q.Length--
+}
+
+// moveToFront relinks node, which is already in the list, right after the
+// head.
+func (q *LinkedList[K, V]) moveToFront(node *Node[K, V]) {
+	if q.Head.Right == node {
+		return
+	}
+
+	node.Left.Right = node.Right
+	node.Right.Left = node.Left
+
+	first := q.Head.Right
+	q.Head.Right = node
+	node.Left = q.Head
+	node.Right = first
+	first.Left = node
+}
+
+type Node[K comparable, V any] struct {
+	Key   K
+	Value V
+	Left  *Node[K, V]
+	Right *Node[K, V]
+
+	// expiresAt is the zero time for entries that never expire; ttl is the
+	// lifetime the entry was stored with. Entries stored with SetWithSWR can
+	// still be served until staleAt while they are revalidated.
+	expiresAt    time.Time
+	ttl          time.Duration
+	staleAt      time.Time
+	stale        time.Duration
+	revalidating bool
+
+	// revalidation is the generation of the latest background reload of
+	// the entry. It is bumped whenever the entry is overwritten, so the
+	// result of an earlier reload is recognized as stale. refreshes counts
+	// the reloads whose results were not applied yet, which still hold the
+	// node.
+	revalidation uint64
+	refreshes    int
+
+	// frequency counts the accesses to the entry under PolicyLFU.
+	frequency int
+
+	// Referenced is set when the entry is accessed under PolicyClock and
+	// cleared when the clock hand passes over it.
+	Referenced bool
+
+	// cost is the share of the cache capacity taken by the entry, 1 unless
+	// it was stored with SetWithCost.
+	cost int
+
+	// size is the estimated memory taken by the entry in bytes, only
+	// tracked when WithMaxBytes is used.
+	size int64
+
+	// accessTimes is a ring buffer of the last accesses under PolicyLRUK,
+	// with accessCursor pointing at the latest one.
+	accessTimes  []time.Time
+	accessCursor int
+
+	// tags are the tags the entry was stored with by SetWithTags.
+	tags []string
+
+	// CreatedAt is when the entry was added to the cache and LastAccessedAt
+	// when it was last found by Get or Check, or added. AccessCount counts
+	// the Get and Check calls which found it.
+	CreatedAt      time.Time
+	LastAccessedAt time.Time
+	AccessCount    int64
+
+	// negative marks a loader "not found" result cached by
+	// WithNegativeCaching.
+	negative bool
+}
+
+type Hash[K comparable, V any] map[K]*Node[K, V]
+
+// Entry is a key-value pair copied out of the cache.
+type Entry[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// New creates an empty cache which holds at most capacity entries. A capacity
+// which is not positive is raised to 1, so the cache never ends up over its
+// capacity with the entry stored last. NewSharded and Resize reject such a
+// capacity with ErrInvalidCapacity instead, as they already return an error:
+// New has returned a bare *Cache since the first release, and reporting the
+// error would break every caller, so it clamps rather than fail.
+func New[K comparable, V any](capacity int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		LinkedList: createLinkedList[K, V](),
+		capacity:   capacity,
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.capacity = max(c.capacity, 1)
+	c.Hash = createHash[K, V](c.capacity)
+	c.policy = newPolicy(c.evictionPolicy, c)
+	if c.ghost != nil && c.ghost.capacity == 0 {
+		c.ghost.capacity = 2 * c.capacity
+	}
+
+	return c
+}
+
+// restore replaces the contents of the cache with entries, given from the
+// least to the most recently used one, and sets its capacity. A zero Cache is
+// initialized like one created by New without options.
+func (c *Cache[K, V]) restore(capacity int, entries []Entry[K, V]) error {
+	if capacity <= 0 {
+		return ErrInvalidCapacity
+	}
+
+	if c.LinkedList.Head == nil {
+		c.LinkedList = createLinkedList[K, V]()
+		c.Hash = createHash[K, V](capacity)
+		c.now = time.Now
+		c.policy = newPolicy(c.evictionPolicy, c)
+	} else {
+		c.clearAll()
+	}
+
+	c.capacity = capacity
+	for _, entry := range entries {
+		c.store(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// maxHashHint caps the number of entries the hash is sized for up front, so a
+// cache whose capacity is counted in cost units does not reserve memory for
+// entries it will never hold.
+const maxHashHint = 1 << 16
+
+// createHash returns a hash sized to hold capacity entries without growing.
+func createHash[K comparable, V any](capacity int) Hash[K, V] {
+	return make(Hash[K, V], max(0, min(capacity, maxHashHint)))
+}
+
+func createLinkedList[K comparable, V any]() LinkedList[K, V] {
+	head := &Node[K, V]{}
+	tail := &Node[K, V]{}
+
+	head.Right = tail
+	tail.Left = head
+
+	return LinkedList[K, V]{
+		Head:   head,
+		Tail:   tail,
+		Length: 0,
+	}
+}
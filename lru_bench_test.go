@@ -0,0 +1,194 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkCheck cycles through twice as many keys as the cache holds, so
+// every Check inserts a new entry and evicts the least recently used one.
+func BenchmarkCheck(b *testing.B) {
+	keys := benchKeys()
+	benchPooling(b, func(b *testing.B, cache *Cache[string, int]) {
+		for i := 0; i < b.N; i++ {
+			cache.Check(keys[i%len(keys)])
+		}
+	})
+}
+
+// BenchmarkFill creates a cache and fills it up to its capacity, with the
+// hash sized up front by New or grown from empty as entries are added.
+func BenchmarkFill(b *testing.B) {
+	keys := make([]string, benchCapacity)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("Element%d", i)
+	}
+
+	for _, presized := range []bool{false, true} {
+		name := "growing"
+		if presized {
+			name = "presized"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				cache := New[string, string](benchCapacity)
+				if !presized {
+					cache.Hash = make(Hash[string, string])
+				}
+				for _, key := range keys {
+					cache.Check(key)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSetMulti writes benchCapacity entries to a SyncCache, one Set at a
+// time or with a single SetMulti.
+func BenchmarkSetMulti(b *testing.B) {
+	entries := make(map[string]string, benchCapacity)
+	for i := 0; i < benchCapacity; i++ {
+		entries[fmt.Sprintf("Element%d", i)] = "Value"
+	}
+
+	b.Run("set", func(b *testing.B) {
+		cache := NewSync[string, string](benchCapacity)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for key, value := range entries {
+				cache.Set(key, value)
+			}
+		}
+	})
+	b.Run("setmulti", func(b *testing.B) {
+		cache := NewSync[string, string](benchCapacity)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cache.SetMulti(entries)
+		}
+	})
+}
+
+// benchKeys returns twice as many keys as the benchmark caches hold, so that
+// cycling through them misses half of the time.
+func benchKeys() []string {
+	keys := make([]string, benchCapacity*2)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("Element%d", i)
+	}
+	return keys
+}
+
+// benchPooling runs fn against a cache recycling its nodes and one which does
+// not, so benchstat can compare the two.
+func benchPooling(b *testing.B, fn func(b *testing.B, cache *Cache[string, int])) {
+	for _, noPool := range []bool{false, true} {
+		name := "pool"
+		if noPool {
+			name = "nopool"
+		}
+		b.Run(name, func(b *testing.B) {
+			cache := New[string, int](benchCapacity)
+			cache.noPool = noPool
+			b.ReportAllocs()
+			fn(b, cache)
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := benchKeys()
+	benchPooling(b, func(b *testing.B, cache *Cache[string, int]) {
+		for _, key := range keys[:benchCapacity] {
+			cache.Set(key, 0)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cache.get(keys[i%benchCapacity])
+		}
+	})
+}
+
+func BenchmarkSet(b *testing.B) {
+	keys := benchKeys()
+	benchPooling(b, func(b *testing.B, cache *Cache[string, int]) {
+		for i := 0; i < b.N; i++ {
+			cache.Set(keys[i%len(keys)], i)
+		}
+	})
+}
+
+func BenchmarkMixedReadWrite(b *testing.B) {
+	keys := benchKeys()
+	benchPooling(b, func(b *testing.B, cache *Cache[string, int]) {
+		for i := 0; i < b.N; i++ {
+			key := keys[i%len(keys)]
+			if i%4 == 0 {
+				cache.Set(key, i)
+			} else {
+				cache.get(key)
+			}
+		}
+	})
+}
+
+func BenchmarkConcurrent8Goroutines(b *testing.B) {
+	benchmarkParallel(b, 8)
+}
+
+func BenchmarkConcurrent32Goroutines(b *testing.B) {
+	benchmarkParallel(b, 32)
+}
+
+// benchmarkParallel runs mixed Get/Set operations on a SyncCache from
+// goroutines per CPU goroutines.
+func benchmarkParallel(b *testing.B, goroutines int) {
+	keys := benchKeys()
+	benchPooling(b, func(b *testing.B, cache *Cache[string, int]) {
+		s := &SyncCache[string, int]{cache: cache}
+		b.SetParallelism(goroutines)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				key := keys[i%len(keys)]
+				if i%4 == 0 {
+					s.Set(key, i)
+				} else {
+					s.Get(key)
+				}
+			}
+		})
+	})
+}
+
+// BenchmarkGetMiss looks up keys which were never cached, with and without a
+// Bloom filter in front of the hash.
+func BenchmarkGetMiss(b *testing.B) {
+	keys := benchKeys()[:benchCapacity]
+	misses := make([]string, benchCapacity)
+	for i := range misses {
+		misses[i] = fmt.Sprintf("Missing%d", i)
+	}
+
+	for _, bloom := range []bool{false, true} {
+		name := "nobloom"
+		var opts []Option[string, string]
+		if bloom {
+			name = "bloom"
+			opts = append(opts, WithBloomFilter[string, string](benchCapacity, 0.01))
+		}
+
+		b.Run(name, func(b *testing.B) {
+			cache := New(benchCapacity, opts...)
+			for _, key := range keys {
+				cache.Set(key, "Value")
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cache.Get(misses[i%len(misses)])
+			}
+		})
+	}
+}
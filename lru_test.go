@@ -0,0 +1,704 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+const testCacheSize = 5
+
+func TestLRUCache(t *testing.T) {
+	cache := New[string, string](testCacheSize)
+
+	// Test adding elements
+	elementsToCache := []string{"Dog", "Cat", "Soda", "Tee", "Dog", "Terry", "Car"}
+	for _, e := range elementsToCache {
+		cache.Check(e)
+	}
+
+	// Test cache behavior
+	expectedCacheState := []string{"Car", "Terry", "Dog", "Tee", "Soda"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	// Test adding more elements than cache size
+	elementsToCache = []string{"Apple", "Banana", "Grape", "Pineapple", "Watermelon"}
+	for _, e := range elementsToCache {
+		cache.Check(e)
+	}
+
+	// Test cache state after adding more elements than cache size
+	expectedCacheState = []string{"Watermelon", "Pineapple", "Grape", "Banana", "Apple"}
+	actualCacheState = getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	cache := New[string, int](3)
+
+	if _, found := cache.Get("Dog"); found {
+		t.Errorf("Expected miss for key not yet cached")
+	}
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+
+	value, found := cache.Get("Dog")
+	if !found || value != 1 {
+		t.Errorf("Expected (1, true), but got: (%d, %t)", value, found)
+	}
+
+	// Updating an existing key replaces its value and promotes it.
+	cache.Set("Cat", 20)
+	cache.Set("Tee", 4)
+
+	if _, found := cache.Get("Soda"); found {
+		t.Errorf("Expected Soda to be evicted as least recently used")
+	}
+
+	value, found = cache.Get("Cat")
+	if !found || value != 20 {
+		t.Errorf("Expected (20, true), but got: (%d, %t)", value, found)
+	}
+
+	expectedCacheState := []string{"Cat", "Tee", "Dog"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	cache := New[string, int](2)
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	cache.SetWithTTL("Cat", 2, time.Second)
+	clock.Advance(2 * time.Second)
+
+	if value, err := cache.Fetch("Dog"); err != nil || value != 1 {
+		t.Errorf("Expected (1, nil), but got: (%d, %v)", value, err)
+	}
+	if _, err := cache.Fetch("Cat"); !errors.Is(err, ErrExpired) {
+		t.Errorf("Expected ErrExpired, but got: %v", err)
+	}
+	// The expired entry was removed, so it is now simply missing.
+	if _, err := cache.Fetch("Cat"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, but got: %v", err)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("Expected 1 hit and 2 misses, but got: %+v", stats)
+	}
+
+	if err := cache.SetWithCost("Soda", 3, 3); !errors.Is(err, ErrCacheFull) {
+		t.Errorf("Expected ErrCostTooHigh to match ErrCacheFull, but got: %v", err)
+	}
+}
+
+func TestGetOrSet(t *testing.T) {
+	cache := New[string, int](2)
+	calls := 0
+	loader := func() (int, error) {
+		calls++
+		return 42, nil
+	}
+
+	value, err := cache.GetOrSet("Dog", loader)
+	if err != nil || value != 42 {
+		t.Errorf("Expected (42, nil), but got: (%d, %v)", value, err)
+	}
+	value, err = cache.GetOrSet("Dog", loader)
+	if err != nil || value != 42 {
+		t.Errorf("Expected (42, nil), but got: (%d, %v)", value, err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected loader to be called once, but got: %d", calls)
+	}
+
+	loadErr := errors.New("load failed")
+	_, err = cache.GetOrSet("Cat", func() (int, error) {
+		return 0, loadErr
+	})
+	if !errors.Is(err, loadErr) {
+		t.Errorf("Expected loader error, but got: %v", err)
+	}
+	if cache.Contains("Cat") {
+		t.Errorf("Expected failed load not to be cached")
+	}
+}
+
+func TestSetNX(t *testing.T) {
+	cache := New[string, int](2)
+
+	if !cache.SetNX("Dog", 1) {
+		t.Errorf("Expected SetNX to store absent key")
+	}
+	cache.Set("Cat", 2)
+	if cache.SetNX("Dog", 10) {
+		t.Errorf("Expected SetNX to reject cached key")
+	}
+	if value, _ := cache.Peek("Dog"); value != 1 {
+		t.Errorf("Expected Dog to keep value 1, but got: %d", value)
+	}
+
+	// The rejected SetNX must not promote Dog.
+	cache.Set("Soda", 3)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Dog to be evicted after rejected SetNX")
+	}
+}
+
+func TestSwap(t *testing.T) {
+	cache := New[string, int](2)
+
+	if old, ok := cache.Swap("Dog", 1); ok || old != 0 {
+		t.Errorf("Expected (0, false) for absent key, but got: (%d, %t)", old, ok)
+	}
+	cache.Set("Cat", 2)
+
+	if old, ok := cache.Swap("Dog", 10); !ok || old != 1 {
+		t.Errorf("Expected (1, true), but got: (%d, %t)", old, ok)
+	}
+	if value, _ := cache.Peek("Dog"); value != 10 {
+		t.Errorf("Expected Dog to hold 10, but got: %d", value)
+	}
+
+	// Swapping must not promote Dog.
+	cache.Set("Soda", 3)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Dog to be evicted after Swap")
+	}
+}
+
+func TestPeek(t *testing.T) {
+	cache := New[string, int](2)
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+
+	value, found := cache.Peek("Dog")
+	if !found || value != 1 {
+		t.Errorf("Expected (1, true), but got: (%d, %t)", value, found)
+	}
+	if _, found := cache.Peek("Soda"); found {
+		t.Errorf("Expected miss for key not cached")
+	}
+
+	// Peeking must not promote Dog, so it is still the next one to go.
+	cache.Set("Soda", 3)
+	if _, found := cache.Peek("Dog"); found {
+		t.Errorf("Expected Dog to be evicted after Peek")
+	}
+}
+
+func TestContains(t *testing.T) {
+	cache := New[string, int](2)
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+
+	if !cache.Contains("Dog") {
+		t.Errorf("Expected Dog to be cached")
+	}
+	if cache.Contains("Soda") {
+		t.Errorf("Expected Soda not to be cached")
+	}
+
+	cache.Set("Soda", 3)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Dog to be evicted after Contains")
+	}
+}
+
+func TestOldestNewest(t *testing.T) {
+	cache := New[string, int](3)
+	if _, _, ok := cache.Oldest(); ok {
+		t.Errorf("Expected Oldest to report empty cache")
+	}
+	if _, _, ok := cache.Newest(); ok {
+		t.Errorf("Expected Newest to report empty cache")
+	}
+
+	for i, e := range []string{"Dog", "Cat", "Soda"} {
+		cache.Set(e, i)
+	}
+	cache.Get("Dog")
+
+	if key, value, ok := cache.Oldest(); !ok || key != "Cat" || value != 1 {
+		t.Errorf("Expected oldest (Cat, 1, true), but got: (%s, %d, %t)", key, value, ok)
+	}
+	if key, value, ok := cache.Newest(); !ok || key != "Dog" || value != 0 {
+		t.Errorf("Expected newest (Dog, 0, true), but got: (%s, %d, %t)", key, value, ok)
+	}
+
+	expectedCacheState := []string{"Dog", "Soda", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected Oldest and Newest to keep cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	cache := New[string, int](3)
+	if keys := cache.Keys(); keys == nil || len(keys) != 0 {
+		t.Errorf("Expected empty non-nil slice, but got: %#v", keys)
+	}
+
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee"} {
+		cache.Set(e, i)
+	}
+	cache.Get("Cat")
+
+	expectedKeys := []string{"Cat", "Tee", "Soda"}
+	actualKeys := cache.Keys()
+	if !equalSlice(expectedKeys, actualKeys) {
+		t.Errorf("Expected keys: %v, but got: %v", expectedKeys, actualKeys)
+	}
+	if len(actualKeys) != cache.Len() {
+		t.Errorf("Expected %d keys, but got: %d", cache.Len(), len(actualKeys))
+	}
+}
+
+func TestValuesEntries(t *testing.T) {
+	cache := New[string, int](3)
+	if values := cache.Values(); values == nil || len(values) != 0 {
+		t.Errorf("Expected empty non-nil slice, but got: %#v", values)
+	}
+	if entries := cache.Entries(); entries == nil || len(entries) != 0 {
+		t.Errorf("Expected empty non-nil slice, but got: %#v", entries)
+	}
+
+	for i, e := range []string{"Dog", "Cat", "Soda"} {
+		cache.Set(e, i)
+	}
+	cache.Get("Dog")
+
+	expectedValues := []int{0, 2, 1}
+	actualValues := cache.Values()
+	if !equalSlice(expectedValues, actualValues) {
+		t.Errorf("Expected values: %v, but got: %v", expectedValues, actualValues)
+	}
+
+	expectedEntries := []Entry[string, int]{{"Dog", 0}, {"Soda", 2}, {"Cat", 1}}
+	actualEntries := cache.Entries()
+	if !equalSlice(expectedEntries, actualEntries) {
+		t.Errorf("Expected entries: %v, but got: %v", expectedEntries, actualEntries)
+	}
+
+	expectedCacheState := []string{"Dog", "Soda", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected iteration to keep cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	cache := New[string, int](3)
+	for i, e := range []string{"Dog", "Cat", "Soda"} {
+		cache.Set(e, i)
+	}
+
+	var visited []string
+	cache.ForEach(func(key string, value int) bool {
+		visited = append(visited, key)
+		return true
+	})
+	expectedKeys := []string{"Soda", "Cat", "Dog"}
+	if !equalSlice(expectedKeys, visited) {
+		t.Errorf("Expected visited keys: %v, but got: %v", expectedKeys, visited)
+	}
+
+	visited = visited[:0]
+	cache.ForEach(func(key string, value int) bool {
+		visited = append(visited, key)
+		return key != "Cat"
+	})
+	expectedKeys = []string{"Soda", "Cat"}
+	if !equalSlice(expectedKeys, visited) {
+		t.Errorf("Expected early stop after Cat, but visited: %v", visited)
+	}
+}
+
+func TestToMapFromMap(t *testing.T) {
+	cache := New[string, int](3)
+	if m := cache.ToMap(); m == nil || len(m) != 0 {
+		t.Errorf("Expected empty non-nil map, but got: %#v", m)
+	}
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	m := cache.ToMap()
+	if len(m) != 2 || m["Dog"] != 1 || m["Cat"] != 2 {
+		t.Errorf("Expected map[Cat:2 Dog:1], but got: %v", m)
+	}
+
+	// The map is a copy.
+	m["Dog"] = 10
+	if value, _ := cache.Peek("Dog"); value != 1 {
+		t.Errorf("Expected the cache to be unaffected by map changes, but got: %d", value)
+	}
+
+	other := New[string, int](3)
+	other.FromMap(map[string]int{"Soda": 3, "Tee": 4})
+	if other.Len() != 2 || !other.Contains("Soda") || !other.Contains("Tee") {
+		t.Errorf("Expected both map entries to be cached, but got: %v", other.Keys())
+	}
+
+	// Maps larger than the cache leave it full.
+	big := map[string]int{}
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee", "Car"} {
+		big[e] = i
+	}
+	other.FromMap(big)
+	if other.Len() != 3 {
+		t.Errorf("Expected Len 3, but got: %d", other.Len())
+	}
+	for key, value := range other.ToMap() {
+		if big[key] != value {
+			t.Errorf("Expected %s to hold %d, but got: %d", key, big[key], value)
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cache := New[string, int](3)
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+
+	if !cache.Delete("Dog") {
+		t.Errorf("Expected Delete to report removal of cached key")
+	}
+	if cache.Delete("Dog") {
+		t.Errorf("Expected Delete to report miss for already removed key")
+	}
+	if _, found := cache.Get("Dog"); found {
+		t.Errorf("Expected Dog to be removed from cache")
+	}
+	if cache.Len() != 1 || len(cache.Hash) != 1 {
+		t.Errorf("Expected list length and hash size of 1, but got: %d and %d", cache.Len(), len(cache.Hash))
+	}
+}
+
+func TestClear(t *testing.T) {
+	cache := New[string, int](3)
+	for i, e := range []string{"Dog", "Cat", "Soda"} {
+		cache.Set(e, i)
+	}
+
+	cache.Clear()
+
+	if cache.Len() != 0 || len(cache.Hash) != 0 {
+		t.Errorf("Expected empty cache, but got Len %d and hash size %d", cache.Len(), len(cache.Hash))
+	}
+	if cache.LinkedList.Head.Right != cache.LinkedList.Tail || cache.LinkedList.Tail.Left != cache.LinkedList.Head {
+		t.Errorf("Expected head and tail to point to each other after Clear")
+	}
+
+	// The cache must remain usable after being cleared.
+	cache.Set("Tee", 4)
+	if value, found := cache.Get("Tee"); !found || value != 4 {
+		t.Errorf("Expected (4, true), but got: (%d, %t)", value, found)
+	}
+}
+
+func TestLenCap(t *testing.T) {
+	cache := New[string, int](2)
+	if cache.Len() != 0 || cache.Cap() != 2 {
+		t.Errorf("Expected Len 0 and Cap 2, but got: %d and %d", cache.Len(), cache.Cap())
+	}
+
+	for i, e := range []string{"Dog", "Cat", "Soda"} {
+		cache.Set(e, i)
+	}
+	if cache.Len() != 2 || cache.Cap() != 2 {
+		t.Errorf("Expected Len 2 and Cap 2, but got: %d and %d", cache.Len(), cache.Cap())
+	}
+}
+
+func TestString(t *testing.T) {
+	cache := New[string, int](3)
+	if actual := cache.String(); actual != "0 - []" {
+		t.Errorf("Expected empty cache string, but got: %q", actual)
+	}
+
+	for i, e := range []string{"Dog", "Cat", "Soda", "Dog"} {
+		cache.Set(e, i)
+	}
+
+	expected := "3 - [{Dog}<-->{Soda}<-->{Cat}]"
+	if actual := cache.String(); actual != expected {
+		t.Errorf("Expected: %q, but got: %q", expected, actual)
+	}
+	if actual := fmt.Sprint(cache); actual != expected {
+		t.Errorf("Expected Cache to implement fmt.Stringer, but got: %q", actual)
+	}
+}
+
+func TestCapacityOne(t *testing.T) {
+	var evicted []string
+	cache := New(1, WithOnEvict(func(key string, value string) {
+		evicted = append(evicted, key)
+	}))
+
+	cache.Check("a")
+	if actual := getCacheState(cache); !equalSlice([]string{"a"}, actual) {
+		t.Errorf("Expected cache state: [a], but got: %v", actual)
+	}
+
+	cache.Check("b")
+	if actual := getCacheState(cache); !equalSlice([]string{"b"}, actual) {
+		t.Errorf("Expected cache state: [b], but got: %v", actual)
+	}
+
+	cache.Check("a")
+	if actual := getCacheState(cache); !equalSlice([]string{"a"}, actual) {
+		t.Errorf("Expected cache state: [a], but got: %v", actual)
+	}
+
+	expectedEvicted := []string{"a", "b"}
+	if !equalSlice(expectedEvicted, evicted) {
+		t.Errorf("Expected evicted keys: %v, but got: %v", expectedEvicted, evicted)
+	}
+	if cache.Len() != 1 || len(cache.Hash) != 1 {
+		t.Errorf("Expected a single entry, but got Len %d and %d hashed keys", cache.Len(), len(cache.Hash))
+	}
+}
+
+func TestInvalidCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		// New has no error to return, so it raises the capacity to 1.
+		t.Run(fmt.Sprintf("New/%d", capacity), func(t *testing.T) {
+			cache := New[string, int](capacity)
+			cache.Set("Dog", 1)
+			cache.Set("Cat", 2)
+
+			if cache.Cap() != 1 || cache.Len() != 1 || !cache.Contains("Cat") {
+				t.Errorf("Expected capacity %d to be raised to 1, but got Cap %d and Len %d", capacity, cache.Cap(), cache.Len())
+			}
+		})
+
+		// Constructors and methods which return an error reject it.
+		t.Run(fmt.Sprintf("NewSharded/%d", capacity), func(t *testing.T) {
+			if cache, err := NewSharded[string, int](capacity, 4); cache != nil || !errors.Is(err, ErrInvalidCapacity) {
+				t.Errorf("Expected ErrInvalidCapacity for capacity %d, but got: %v", capacity, err)
+			}
+		})
+		t.Run(fmt.Sprintf("Resize/%d", capacity), func(t *testing.T) {
+			cache := New[string, int](2)
+			if err := cache.Resize(capacity); !errors.Is(err, ErrInvalidCapacity) || cache.Cap() != 2 {
+				t.Errorf("Expected ErrInvalidCapacity and Cap 2 for capacity %d, but got: %v and %d", capacity, err, cache.Cap())
+			}
+		})
+	}
+}
+
+func TestWithCapacity(t *testing.T) {
+	cache := New(0, WithCapacity[string, int](2))
+	for i, e := range []string{"Dog", "Cat", "Soda"} {
+		cache.Set(e, i)
+	}
+
+	if cache.Len() != 2 || cache.Cap() != 2 {
+		t.Errorf("Expected Len 2 and Cap 2, but got: %d and %d", cache.Len(), cache.Cap())
+	}
+}
+
+func TestResize(t *testing.T) {
+	cache := New[string, int](4)
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee"} {
+		cache.Set(e, i)
+	}
+
+	if err := cache.Resize(2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedCacheState := []string{"Tee", "Soda"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if cache.Cap() != 2 || len(cache.Hash) != 2 {
+		t.Errorf("Expected Cap 2 and hash size 2, but got: %d and %d", cache.Cap(), len(cache.Hash))
+	}
+
+	// Growing keeps every entry and makes room for new ones.
+	if err := cache.Resize(3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cache.Set("Terry", 5)
+	if cache.Len() != 3 {
+		t.Errorf("Expected Len 3 after growing, but got: %d", cache.Len())
+	}
+
+	for _, capacity := range []int{0, -1} {
+		if err := cache.Resize(capacity); !errors.Is(err, ErrInvalidCapacity) {
+			t.Errorf("Expected ErrInvalidCapacity for capacity %d, but got: %v", capacity, err)
+		}
+	}
+	if cache.Cap() != 3 {
+		t.Errorf("Expected failed Resize to keep Cap 3, but got: %d", cache.Cap())
+	}
+}
+
+func TestOnEvict(t *testing.T) {
+	var evicted []Entry[string, int]
+	cache := New(2, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, Entry[string, int]{key, value})
+	}))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Get("Dog")
+	cache.Check("Cat")
+	if len(evicted) != 0 {
+		t.Errorf("Expected promotions not to evict, but got: %v", evicted)
+	}
+
+	cache.Set("Soda", 3)
+	cache.Delete("Cat")
+	cache.Set("Tee", 4)
+	cache.Clear()
+
+	expectedEvicted := []Entry[string, int]{{"Dog", 1}, {"Cat", 2}, {"Soda", 3}, {"Tee", 4}}
+	if !equalSlice(expectedEvicted, evicted) {
+		t.Errorf("Expected evicted entries: %v, but got: %v", expectedEvicted, evicted)
+	}
+}
+
+func TestOnEvictChained(t *testing.T) {
+	var calls []string
+	cache := New(1,
+		WithOnEvict(func(key string, _ int) { calls = append(calls, "first "+key) }),
+		WithOnEvict(func(key string, _ int) { calls = append(calls, "second "+key) }),
+		WithOnHit(func(key string, _ int) { calls = append(calls, "hit "+key) }),
+		WithOnHit(func(key string, _ int) { calls = append(calls, "hit again "+key) }),
+		WithOnMiss[string, int](func(key string) { calls = append(calls, "miss "+key) }),
+		WithOnMiss[string, int](func(key string) { calls = append(calls, "miss again "+key) }),
+	)
+
+	cache.Set("Dog", 1)
+	cache.Get("Dog")
+	cache.Get("Cat")
+	cache.Set("Cat", 2)
+
+	expected := []string{"hit Dog", "hit again Dog", "miss Cat", "miss again Cat", "first Dog", "second Dog"}
+	if !equalSlice(expected, calls) {
+		t.Errorf("Expected every hook in option order: %v, but got: %v", expected, calls)
+	}
+}
+
+func TestOnEvictDuringAdd(t *testing.T) {
+	var evicted []Entry[string, int]
+	cache := New(2, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, Entry[string, int]{key, value})
+	}))
+
+	for i, key := range []string{"Dog", "Cat", "Soda"} {
+		node := &Node[string, int]{Key: key, Value: i + 1, cost: 1}
+		cache.Hash[key] = node
+		cache.Add(node)
+	}
+
+	// The callback sees the evicted entry, not the one being added.
+	expectedEvicted := []Entry[string, int]{{"Dog", 1}}
+	if !equalSlice(expectedEvicted, evicted) {
+		t.Errorf("Expected evicted entries: %v, but got: %v", expectedEvicted, evicted)
+	}
+	if _, ok := cache.Hash["Dog"]; ok || cache.Len() != 2 {
+		t.Errorf("Expected Dog to be unlinked and unhashed, but got Len: %d", cache.Len())
+	}
+}
+
+func TestOnHitOnMiss(t *testing.T) {
+	var hits, misses []string
+	cache := New(2,
+		WithOnHit(func(key string, value int) {
+			hits = append(hits, key)
+		}),
+		WithOnMiss[string, int](func(key string) {
+			misses = append(misses, key)
+		}),
+	)
+
+	cache.Get("Dog")
+	cache.Set("Dog", 1)
+	cache.Get("Dog")
+	cache.Peek("Dog")
+	cache.Contains("Cat")
+	cache.Get("Cat")
+
+	if expectedHits := []string{"Dog"}; !equalSlice(expectedHits, hits) {
+		t.Errorf("Expected hits: %v, but got: %v", expectedHits, hits)
+	}
+	if expectedMisses := []string{"Dog", "Cat"}; !equalSlice(expectedMisses, misses) {
+		t.Errorf("Expected misses: %v, but got: %v", expectedMisses, misses)
+	}
+}
+
+func TestLRUCacheIndependentCapacities(t *testing.T) {
+	small := New[string, string](2)
+	large := New[string, string](4)
+
+	elementsToCache := []string{"Dog", "Cat", "Soda", "Tee"}
+	for _, e := range elementsToCache {
+		small.Check(e)
+		large.Check(e)
+	}
+
+	expectedCacheState := []string{"Tee", "Soda"}
+	actualCacheState := getCacheState(small)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected small cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	expectedCacheState = []string{"Tee", "Soda", "Cat", "Dog"}
+	actualCacheState = getCacheState(large)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected large cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestLRUCacheGenericKeys(t *testing.T) {
+	type point struct{ X, Y int }
+
+	cache := New[point, int](2)
+	cache.Check(point{1, 1})
+	cache.Check(point{2, 2})
+	cache.Check(point{1, 1})
+	cache.Check(point{3, 3})
+
+	if _, ok := cache.Hash[point{2, 2}]; ok {
+		t.Errorf("Expected %v to be evicted", point{2, 2})
+	}
+	if cache.LinkedList.Head.Right.Key != (point{3, 3}) {
+		t.Errorf("Expected most recent key: %v, but got: %v", point{3, 3}, cache.LinkedList.Head.Right.Key)
+	}
+}
+
+func getCacheState[V any](cache *Cache[string, V]) []string {
+	state := make([]string, 0, cache.LinkedList.Length)
+	node := cache.LinkedList.Head.Right
+	for i := 0; i < cache.LinkedList.Length; i++ {
+		state = append(state, node.Key)
+		node = node.Right
+	}
+	return state
+}
+
+func equalSlice[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
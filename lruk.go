@@ -0,0 +1,135 @@
+package lru
+
+import (
+	"container/heap"
+	"time"
+)
+
+// PolicyLRUK returns an LRU-K policy, which evicts the entry whose k-th most
+// recent access is the oldest. Entries accessed fewer than k times have no
+// k-th access yet and are evicted first, starting from the one first accessed
+// longest ago. With k of 2 or more, entries touched once by a scan are
+// therefore evicted before entries used repeatedly; a k of 1 is plain LRU. A
+// k below 1 is treated as 1.
+func PolicyLRUK(k int) EvictionPolicy {
+	return EvictionPolicy{kind: policyLRUK, k: max(1, k)}
+}
+
+// lrukPolicy keeps the cached nodes in a min-heap ordered by whether they have
+// k accesses yet and then by their k-th most recent access. Ties, which happen when the clock does not advance between
+// accesses, are broken by the order of the latest accesses.
+type lrukPolicy[K comparable, V any] struct {
+	k     int
+	now   func() time.Time
+	seq   uint64
+	items lrukHeap[K, V]
+	index map[*Node[K, V]]*lrukItem[K, V]
+}
+
+type lrukItem[K comparable, V any] struct {
+	node *Node[K, V]
+	seq  uint64
+	pos  int
+}
+
+func newLRUKPolicy[K comparable, V any](k int, now func() time.Time) *lrukPolicy[K, V] {
+	return &lrukPolicy[K, V]{k: k, now: now, index: map[*Node[K, V]]*lrukItem[K, V]{}}
+}
+
+func (p *lrukPolicy[K, V]) add(node *Node[K, V]) {
+	node.accessTimes, node.accessCursor = make([]time.Time, 0, p.k), 0
+	item := &lrukItem[K, V]{node: node}
+	p.record(item)
+	p.index[node] = item
+	heap.Push(&p.items, item)
+}
+
+func (p *lrukPolicy[K, V]) access(node *Node[K, V]) bool {
+	item := p.index[node]
+	p.record(item)
+	heap.Fix(&p.items, item.pos)
+	return true
+}
+
+func (p *lrukPolicy[K, V]) remove(node *Node[K, V]) {
+	if item, ok := p.index[node]; ok {
+		heap.Remove(&p.items, item.pos)
+		delete(p.index, node)
+	}
+}
+
+func (p *lrukPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	return p.items[0].node
+}
+
+func (p *lrukPolicy[K, V]) clear() {
+	p.items = nil
+	p.index = map[*Node[K, V]]*lrukItem[K, V]{}
+}
+
+// record stores the current time in the access history of the node of item,
+// overwriting the oldest access once k of them are kept.
+func (p *lrukPolicy[K, V]) record(item *lrukItem[K, V]) {
+	node := item.node
+	if len(node.accessTimes) < p.k {
+		node.accessTimes = append(node.accessTimes, p.now())
+		node.accessCursor = len(node.accessTimes) - 1
+	} else {
+		node.accessCursor = (node.accessCursor + 1) % p.k
+		node.accessTimes[node.accessCursor] = p.now()
+	}
+
+	p.seq++
+	item.seq = p.seq
+}
+
+// hasKAccesses reports whether node was accessed at least k times.
+func (n *Node[K, V]) hasKAccesses() bool {
+	return len(n.accessTimes) == cap(n.accessTimes)
+}
+
+// kthAccess returns the k-th most recent access time of node, or its first
+// access if it was accessed fewer than k times.
+func (n *Node[K, V]) kthAccess() time.Time {
+	if !n.hasKAccesses() {
+		return n.accessTimes[0]
+	}
+	return n.accessTimes[(n.accessCursor+1)%len(n.accessTimes)]
+}
+
+// lrukHeap implements heap.Interface for lrukPolicy.
+type lrukHeap[K comparable, V any] []*lrukItem[K, V]
+
+func (h lrukHeap[K, V]) Len() int { return len(h) }
+
+func (h lrukHeap[K, V]) Less(i, j int) bool {
+	if fi, fj := h[i].node.hasKAccesses(), h[j].node.hasKAccesses(); fi != fj {
+		return fj
+	}
+
+	a, b := h[i].node.kthAccess(), h[j].node.kthAccess()
+	if !a.Equal(b) {
+		return a.Before(b)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h lrukHeap[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos = i
+	h[j].pos = j
+}
+
+func (h *lrukHeap[K, V]) Push(x any) {
+	item := x.(*lrukItem[K, V])
+	item.pos = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *lrukHeap[K, V]) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,111 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPolicyLRUK(t *testing.T) {
+	cache := New(3, WithEvictionPolicy[string, int](PolicyLRUK(2)))
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	clock.Advance(time.Second)
+	cache.Set("Cat", 2)
+	clock.Advance(time.Second)
+	cache.Get("Dog")
+	clock.Advance(time.Second)
+	cache.Set("Soda", 3)
+	clock.Advance(time.Second)
+
+	// Cat and Soda were accessed once, so they go before Dog; Cat was first
+	// accessed longer ago.
+	cache.Set("Tee", 4)
+	if cache.Contains("Cat") || !cache.Contains("Dog") {
+		t.Errorf("Expected Cat to be evicted before Dog which was accessed twice")
+	}
+
+	clock.Advance(time.Second)
+	cache.Get("Soda")
+	clock.Advance(time.Second)
+	cache.Get("Tee")
+	clock.Advance(time.Second)
+
+	// Dog: 0s and 2s, Soda: 3s and 5s, Tee: 4s and 6s. Dog's second most
+	// recent access is the oldest.
+	cache.Set("Car", 5)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Dog to be evicted")
+	}
+
+	// Car was accessed once and goes before the others.
+	clock.Advance(time.Second)
+	cache.Set("Terry", 6)
+	if cache.Contains("Car") || !cache.Contains("Soda") || !cache.Contains("Tee") {
+		t.Errorf("Expected Car to be evicted before Soda and Tee")
+	}
+}
+
+func TestPolicyLRUKRingBuffer(t *testing.T) {
+	cache := New(3, WithEvictionPolicy[string, int](PolicyLRUK(3)))
+	clock := newTestClock(cache)
+	start := clock.Now()
+
+	cache.Set("Dog", 1)
+	for i := 1; i <= 4; i++ {
+		clock.Advance(time.Second)
+		cache.Get("Dog")
+	}
+
+	// The last three accesses were at 2s, 3s and 4s.
+	node := cache.Hash["Dog"]
+	if len(node.accessTimes) != 3 {
+		t.Fatalf("Expected 3 access times to be kept, but got: %d", len(node.accessTimes))
+	}
+	if kth := node.kthAccess(); !kth.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("Expected third most recent access at 2s, but got: %s", kth.Sub(start))
+	}
+}
+
+// With k of 1 the policy must evict exactly like the default LRU policy.
+func TestPolicyLRUKOneIsLRU(t *testing.T) {
+	lru := New[string, int](8)
+	lruk := New(8, WithEvictionPolicy[string, int](PolicyLRUK(1)))
+
+	for i := 0; i < 2000; i++ {
+		key := fmt.Sprintf("Element%d", (i*7919)%23)
+		for _, cache := range []*Cache[string, int]{lru, lruk} {
+			switch i % 3 {
+			case 0:
+				cache.Set(key, i)
+			case 1:
+				cache.Get(key)
+			default:
+				cache.Check(key)
+			}
+		}
+
+		if !equalSlice(lru.Keys(), lruk.Keys()) {
+			t.Fatalf("Expected LRU-1 state %v to match LRU state %v after %d operations", lruk.Keys(), lru.Keys(), i+1)
+		}
+	}
+}
+
+func TestPolicyLRUKScan(t *testing.T) {
+	cache := New(100, WithEvictionPolicy[int, int](PolicyLRUK(2)))
+
+	for i := 0; i < 50; i++ {
+		cache.Set(-i-1, i)
+		cache.Get(-i - 1)
+	}
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+
+	for i := 0; i < 50; i++ {
+		if !cache.Contains(-i - 1) {
+			t.Fatalf("Expected entry %d used twice to survive a scan", -i-1)
+		}
+	}
+}
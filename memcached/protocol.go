@@ -0,0 +1,84 @@
+package memcached
+
+import "errors"
+
+// The constants below follow the memcached binary protocol specification.
+
+const (
+	headerSize    = 24
+	magicRequest  = 0x80
+	magicResponse = 0x81
+
+	// maxBodySize bounds the body of a request, which holds the extras, key
+	// and value: memcached's default 1 MiB item limit plus room for the rest.
+	maxBodySize = 1<<20 + 512
+
+	// maxRelativeExpiration is the largest expiration taken as a number of
+	// seconds rather than a Unix time: 30 days.
+	maxRelativeExpiration = 60 * 60 * 24 * 30
+)
+
+const (
+	opGet      = 0x00
+	opSet      = 0x01
+	opAdd      = 0x02
+	opReplace  = 0x03
+	opDelete   = 0x04
+	opQuit     = 0x07
+	opFlush    = 0x08
+	opGetQ     = 0x09
+	opNoop     = 0x0a
+	opVersion  = 0x0b
+	opGetK     = 0x0c
+	opGetKQ    = 0x0d
+	opStat     = 0x10
+	opSetQ     = 0x11
+	opAddQ     = 0x12
+	opReplaceQ = 0x13
+	opDeleteQ  = 0x14
+	opQuitQ    = 0x17
+	opFlushQ   = 0x18
+)
+
+const (
+	statusOK               = 0x0000
+	statusKeyNotFound      = 0x0001
+	statusKeyExists        = 0x0002
+	statusValueTooLarge    = 0x0003
+	statusInvalidArguments = 0x0004
+	statusNotStored        = 0x0005
+	statusUnknownCommand   = 0x0081
+)
+
+var statusText = map[uint16]string{
+	statusKeyNotFound:      "Not found",
+	statusKeyExists:        "Data exists for key.",
+	statusValueTooLarge:    "Too large.",
+	statusInvalidArguments: "Invalid arguments",
+	statusNotStored:        "Not stored.",
+	statusUnknownCommand:   "Unknown command",
+}
+
+var (
+	errBadMagic  = errors.New("memcached: bad request magic")
+	errBadLength = errors.New("memcached: bad request length")
+)
+
+type request struct {
+	opcode byte
+	opaque uint32
+	cas    uint64
+	extras []byte
+	key    []byte
+	value  []byte
+}
+
+// quiet reports whether the request is a quiet variant, which is not
+// answered on success, or for gets, on a miss.
+func (r *request) quiet() bool {
+	switch r.opcode {
+	case opGetQ, opGetKQ, opSetQ, opAddQ, opReplaceQ, opDeleteQ, opQuitQ, opFlushQ:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,451 @@
+// Package memcached serves an lru cache over TCP with the memcached binary
+// protocol, so existing memcached clients can use it. The get, set, add,
+// replace, delete, flush, stat, noop, version and quit commands are
+// supported, including their quiet variants.
+package memcached
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+// ErrServerClosed is returned by Serve and ListenAndServe once Close was
+// called.
+var ErrServerClosed = errors.New("memcached: server closed")
+
+// Version is reported by the version command.
+const Version = "1.6.0"
+
+// Item is a value stored by the server, with the flags and CAS token of the
+// memcached protocol kept next to it.
+type Item struct {
+	Value []byte
+	Flags uint32
+	CAS   uint64
+}
+
+// Server implements the memcached binary protocol on top of a Cache.
+type Server struct {
+	cache   *lru.Cache[string, Item]
+	started time.Time
+	now     func() time.Time
+
+	// mu makes the check of an existing item and the update that depends
+	// on it atomic for add, replace, CAS and delete. Single cache calls need
+	// no lock, as the cache guards itself.
+	mu  sync.Mutex
+	cas uint64
+
+	connMu    sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// NewServer creates a Server for a new cache which holds at most capacity
+// items, created with opts.
+func NewServer(capacity int, opts ...lru.Option[string, Item]) *Server {
+	return &Server{
+		cache:     lru.New(capacity, opts...),
+		started:   time.Now(),
+		now:       time.Now,
+		listeners: map[net.Listener]struct{}{},
+		conns:     map[net.Conn]struct{}{},
+	}
+}
+
+// ListenAndServe listens on the TCP address addr and calls Serve.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts connections on l and serves each of them on its own
+// goroutine, until l fails or Close is called. It closes l before returning.
+func (s *Server) Serve(l net.Listener) error {
+	if !s.track(l, nil) {
+		l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrack(l, nil)
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			return err
+		}
+		if !s.track(nil, conn) {
+			conn.Close()
+			return ErrServerClosed
+		}
+
+		go func() {
+			defer s.untrack(nil, conn)
+			defer conn.Close()
+			s.serveConn(conn)
+		}()
+	}
+}
+
+// Close stops every Serve call and closes all connections, waiting for them
+// to finish.
+func (s *Server) Close() error {
+	s.connMu.Lock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.connMu.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+// track registers a listener or a connection, reporting false once the
+// server is closed.
+func (s *Server) track(l net.Listener, conn net.Conn) bool {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	if s.closed {
+		return false
+	}
+	if l != nil {
+		s.listeners[l] = struct{}{}
+	}
+	if conn != nil {
+		s.conns[conn] = struct{}{}
+	}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *Server) untrack(l net.Listener, conn net.Conn) {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	delete(s.listeners, l)
+	delete(s.conns, conn)
+	s.wg.Done()
+}
+
+func (s *Server) isClosed() bool {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	return s.closed
+}
+
+// serveConn handles the requests of conn until it is closed, fails, or
+// sends a malformed request or quit. Responses are flushed once no further
+// request is buffered, so pipelined quiet commands are answered together.
+func (s *Server) serveConn(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	for {
+		req, err := readRequest(r)
+		if err != nil {
+			return
+		}
+
+		quit := s.handle(w, req)
+		if quit || r.Buffered() == 0 {
+			if err := w.Flush(); err != nil || quit {
+				return
+			}
+		}
+	}
+}
+
+// handle answers req, reporting whether the connection should be closed.
+func (s *Server) handle(w *bufio.Writer, req *request) bool {
+	switch req.opcode {
+	case opGet, opGetQ, opGetK, opGetKQ:
+		s.get(w, req)
+	case opSet, opSetQ, opAdd, opAddQ, opReplace, opReplaceQ:
+		s.store(w, req)
+	case opDelete, opDeleteQ:
+		s.delete(w, req)
+	case opFlush, opFlushQ:
+		s.flush(w, req)
+	case opStat:
+		s.stat(w, req)
+	case opNoop:
+		writeResponse(w, req, statusOK, 0, nil, nil, nil)
+	case opVersion:
+		writeResponse(w, req, statusOK, 0, nil, nil, []byte(Version))
+	case opQuit:
+		writeResponse(w, req, statusOK, 0, nil, nil, nil)
+		return true
+	case opQuitQ:
+		return true
+	default:
+		writeError(w, req, statusUnknownCommand)
+	}
+	return false
+}
+
+func (s *Server) get(w *bufio.Writer, req *request) {
+	if len(req.extras) != 0 || len(req.key) == 0 || len(req.value) != 0 {
+		writeError(w, req, statusInvalidArguments)
+		return
+	}
+	withKey := req.opcode == opGetK || req.opcode == opGetKQ
+
+	item, found := s.cache.Get(string(req.key))
+
+	if !found {
+		if req.quiet() {
+			return
+		}
+		if withKey {
+			writeResponse(w, req, statusKeyNotFound, 0, nil, req.key, nil)
+			return
+		}
+		writeError(w, req, statusKeyNotFound)
+		return
+	}
+
+	extras := binary.BigEndian.AppendUint32(nil, item.Flags)
+	var key []byte
+	if withKey {
+		key = req.key
+	}
+	writeResponse(w, req, statusOK, item.CAS, extras, key, item.Value)
+}
+
+func (s *Server) store(w *bufio.Writer, req *request) {
+	if len(req.extras) != 8 || len(req.key) == 0 {
+		writeError(w, req, statusInvalidArguments)
+		return
+	}
+	flags := binary.BigEndian.Uint32(req.extras)
+	ttl, expired := s.ttl(binary.BigEndian.Uint32(req.extras[4:]))
+	key := string(req.key)
+
+	s.mu.Lock()
+	current, found := s.cache.Peek(key)
+	status := uint16(statusOK)
+	switch {
+	case req.opcode == opAdd || req.opcode == opAddQ:
+		if found {
+			status = statusKeyExists
+		}
+	case req.opcode == opReplace || req.opcode == opReplaceQ:
+		if !found {
+			status = statusKeyNotFound
+		}
+	}
+	if status == statusOK && req.cas != 0 {
+		status = casStatus(current, found, req.cas)
+	}
+
+	var item Item
+	if status == statusOK {
+		s.cas++
+		item = Item{Value: req.value, Flags: flags, CAS: s.cas}
+		if expired {
+			// An expiration in the past stores an item which is gone
+			// right away.
+			s.cache.Delete(key)
+		} else if err := s.cache.SetWithTTL(key, item, ttl); err != nil {
+			status = storeStatus(err)
+		}
+	}
+	s.mu.Unlock()
+
+	if status != statusOK {
+		writeError(w, req, status)
+		return
+	}
+	if !req.quiet() {
+		writeResponse(w, req, statusOK, item.CAS, nil, nil, nil)
+	}
+}
+
+func (s *Server) delete(w *bufio.Writer, req *request) {
+	if len(req.extras) != 0 || len(req.key) == 0 || len(req.value) != 0 {
+		writeError(w, req, statusInvalidArguments)
+		return
+	}
+	key := string(req.key)
+
+	s.mu.Lock()
+	current, found := s.cache.Peek(key)
+	status := casStatus(current, found, req.cas)
+	if status == statusOK {
+		s.cache.Delete(key)
+	}
+	s.mu.Unlock()
+
+	if status != statusOK {
+		writeError(w, req, status)
+		return
+	}
+	if !req.quiet() {
+		writeResponse(w, req, statusOK, 0, nil, nil, nil)
+	}
+}
+
+// flush removes every item. A delayed flush is not supported: the cache is
+// cleared right away whatever expiration the request carries.
+func (s *Server) flush(w *bufio.Writer, req *request) {
+	if (len(req.extras) != 0 && len(req.extras) != 4) || len(req.key) != 0 || len(req.value) != 0 {
+		writeError(w, req, statusInvalidArguments)
+		return
+	}
+
+	s.cache.Clear()
+
+	if !req.quiet() {
+		writeResponse(w, req, statusOK, 0, nil, nil, nil)
+	}
+}
+
+// stat sends the general statistics, one response per statistic, followed
+// by a response with an empty key. Statistics groups are not supported.
+func (s *Server) stat(w *bufio.Writer, req *request) {
+	if len(req.key) != 0 {
+		writeError(w, req, statusKeyNotFound)
+		return
+	}
+
+	stats := s.cache.Stats()
+	items := s.cache.Len()
+
+	s.connMu.Lock()
+	conns := len(s.conns)
+	s.connMu.Unlock()
+
+	now := s.now()
+	for _, stat := range [][2]string{
+		{"pid", strconv.Itoa(os.Getpid())},
+		{"uptime", strconv.FormatInt(int64(now.Sub(s.started)/time.Second), 10)},
+		{"time", strconv.FormatInt(now.Unix(), 10)},
+		{"version", Version},
+		{"curr_connections", strconv.Itoa(conns)},
+		{"curr_items", strconv.Itoa(items)},
+		{"total_items", strconv.FormatUint(stats.Insertions, 10)},
+		{"get_hits", strconv.FormatUint(stats.Hits, 10)},
+		{"get_misses", strconv.FormatUint(stats.Misses, 10)},
+		{"evictions", strconv.FormatUint(stats.Evictions, 10)},
+	} {
+		writeResponse(w, req, statusOK, 0, nil, []byte(stat[0]), []byte(stat[1]))
+	}
+	writeResponse(w, req, statusOK, 0, nil, nil, nil)
+}
+
+// ttl converts a memcached expiration into a TTL for SetWithTTL. Expirations
+// up to 30 days are relative, in seconds; larger ones are Unix times. It
+// reports whether an absolute expiration has already passed.
+func (s *Server) ttl(exptime uint32) (time.Duration, bool) {
+	switch {
+	case exptime == 0:
+		return 0, false
+	case exptime <= maxRelativeExpiration:
+		return time.Duration(exptime) * time.Second, false
+	default:
+		ttl := time.Unix(int64(exptime), 0).Sub(s.now())
+		return ttl, ttl <= 0
+	}
+}
+
+// casStatus checks the CAS token of a request against the current item: 0
+// matches any item, anything else only an item with that token.
+func casStatus(current Item, found bool, cas uint64) uint16 {
+	switch {
+	case !found:
+		return statusKeyNotFound
+	case cas != 0 && current.CAS != cas:
+		return statusKeyExists
+	default:
+		return statusOK
+	}
+}
+
+// storeStatus maps an error of SetWithTTL to a status.
+func storeStatus(err error) uint16 {
+	if errors.Is(err, lru.ErrEntryTooLarge) {
+		return statusValueTooLarge
+	}
+	return statusNotStored
+}
+
+// readRequest reads the next request of r. It fails for malformed requests,
+// after which the connection cannot be resynchronized.
+func readRequest(r io.Reader) (*request, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	if header[0] != magicRequest {
+		return nil, errBadMagic
+	}
+
+	req := &request{
+		opcode: header[1],
+		opaque: binary.BigEndian.Uint32(header[12:]),
+		cas:    binary.BigEndian.Uint64(header[16:]),
+	}
+	keyLen := int(binary.BigEndian.Uint16(header[2:]))
+	extrasLen := int(header[4])
+	bodyLen := int(binary.BigEndian.Uint32(header[8:]))
+	if bodyLen > maxBodySize || extrasLen+keyLen > bodyLen {
+		return nil, errBadLength
+	}
+
+	body := make([]byte, bodyLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	req.extras = body[:extrasLen]
+	req.key = body[extrasLen : extrasLen+keyLen]
+	req.value = body[extrasLen+keyLen:]
+	return req, nil
+}
+
+// writeResponse writes a response to req. Write errors are left to the
+// flush.
+func writeResponse(w *bufio.Writer, req *request, status uint16, cas uint64, extras, key, value []byte) {
+	var header [headerSize]byte
+	header[0] = magicResponse
+	header[1] = req.opcode
+	binary.BigEndian.PutUint16(header[2:], uint16(len(key)))
+	header[4] = uint8(len(extras))
+	binary.BigEndian.PutUint16(header[6:], status)
+	binary.BigEndian.PutUint32(header[8:], uint32(len(extras)+len(key)+len(value)))
+	binary.BigEndian.PutUint32(header[12:], req.opaque)
+	binary.BigEndian.PutUint64(header[16:], cas)
+
+	w.Write(header[:])
+	w.Write(extras)
+	w.Write(key)
+	w.Write(value)
+}
+
+// writeError writes a response with status and its message as the value.
+// Errors are sent for quiet commands too.
+func writeError(w *bufio.Writer, req *request, status uint16) {
+	writeResponse(w, req, status, 0, nil, nil, []byte(statusText[status]))
+}
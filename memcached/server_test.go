@@ -0,0 +1,258 @@
+package memcached
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dial starts server on a local port and returns a connection to it.
+func dial(t *testing.T, server *Server) net.Conn {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(l) }()
+	t.Cleanup(func() {
+		server.Close()
+		if err := <-done; !errors.Is(err, ErrServerClosed) {
+			t.Errorf("Expected Serve to return ErrServerClosed, but got: %v", err)
+		}
+	})
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// TestSpecExamples sends the example packets of the binary protocol
+// specification and expects its example responses byte for byte.
+func TestSpecExamples(t *testing.T) {
+	conn := dial(t, NewServer(10))
+
+	examples := []struct {
+		name, request, response string
+	}{
+		{
+			"get miss",
+			"80 00 00 05 00 00 00 00 00 00 00 05 00 00 00 00 00 00 00 00 00 00 00 00 48 65 6c 6c 6f",
+			"81 00 00 00 00 00 00 01 00 00 00 09 00 00 00 00 00 00 00 00 00 00 00 00 4e 6f 74 20 66 6f 75 6e 64",
+		},
+		{
+			"add",
+			"80 02 00 05 08 00 00 00 00 00 00 12 00 00 00 00 00 00 00 00 00 00 00 00 de ad be ef 00 00 0e 10 48 65 6c 6c 6f 57 6f 72 6c 64",
+			"81 02 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 01",
+		},
+		{
+			"get hit",
+			"80 00 00 05 00 00 00 00 00 00 00 05 00 00 00 00 00 00 00 00 00 00 00 00 48 65 6c 6c 6f",
+			"81 00 00 00 04 00 00 00 00 00 00 09 00 00 00 00 00 00 00 00 00 00 00 01 de ad be ef 57 6f 72 6c 64",
+		},
+		{
+			"getk hit",
+			"80 0c 00 05 00 00 00 00 00 00 00 05 00 00 00 00 00 00 00 00 00 00 00 00 48 65 6c 6c 6f",
+			"81 0c 00 05 04 00 00 00 00 00 00 0e 00 00 00 00 00 00 00 00 00 00 00 01 de ad be ef 48 65 6c 6c 6f 57 6f 72 6c 64",
+		},
+		{
+			"delete",
+			"80 04 00 05 00 00 00 00 00 00 00 05 00 00 00 00 00 00 00 00 00 00 00 00 48 65 6c 6c 6f",
+			"81 04 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00",
+		},
+	}
+	for _, ex := range examples {
+		if _, err := conn.Write(unhex(t, ex.request)); err != nil {
+			t.Fatal(err)
+		}
+		expected := unhex(t, ex.response)
+		got := make([]byte, len(expected))
+		if _, err := io.ReadFull(conn, got); err != nil {
+			t.Fatalf("%s: %v", ex.name, err)
+		}
+		if !bytes.Equal(got, expected) {
+			t.Errorf("%s: expected % x, but got: % x", ex.name, expected, got)
+		}
+	}
+}
+
+type response struct {
+	opcode byte
+	status uint16
+	opaque uint32
+	cas    uint64
+	extras []byte
+	key    string
+	value  string
+}
+
+// client speaks the binary protocol over conn.
+type client struct {
+	t    *testing.T
+	conn net.Conn
+}
+
+func (c *client) send(opcode byte, opaque uint32, cas uint64, extras []byte, key, value string) {
+	c.t.Helper()
+
+	header := make([]byte, 24)
+	header[0] = magicRequest
+	header[1] = opcode
+	binary.BigEndian.PutUint16(header[2:], uint16(len(key)))
+	header[4] = uint8(len(extras))
+	binary.BigEndian.PutUint32(header[8:], uint32(len(extras)+len(key)+len(value)))
+	binary.BigEndian.PutUint32(header[12:], opaque)
+	binary.BigEndian.PutUint64(header[16:], cas)
+
+	packet := append(append(append(header, extras...), key...), value...)
+	if _, err := c.conn.Write(packet); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *client) receive() response {
+	c.t.Helper()
+
+	header := make([]byte, 24)
+	if _, err := io.ReadFull(c.conn, header); err != nil {
+		c.t.Fatal(err)
+	}
+	if header[0] != magicResponse {
+		c.t.Fatalf("Expected a response magic, but got: %#x", header[0])
+	}
+	body := make([]byte, binary.BigEndian.Uint32(header[8:]))
+	if _, err := io.ReadFull(c.conn, body); err != nil {
+		c.t.Fatal(err)
+	}
+
+	keyLen, extrasLen := int(binary.BigEndian.Uint16(header[2:])), int(header[4])
+	return response{
+		opcode: header[1],
+		status: binary.BigEndian.Uint16(header[6:]),
+		opaque: binary.BigEndian.Uint32(header[12:]),
+		cas:    binary.BigEndian.Uint64(header[16:]),
+		extras: body[:extrasLen],
+		key:    string(body[extrasLen : extrasLen+keyLen]),
+		value:  string(body[extrasLen+keyLen:]),
+	}
+}
+
+func (c *client) do(opcode byte, cas uint64, extras []byte, key, value string) response {
+	c.t.Helper()
+
+	c.send(opcode, 0, cas, extras, key, value)
+	return c.receive()
+}
+
+func setExtras(flags, exptime uint32) []byte {
+	return binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, flags), exptime)
+}
+
+func TestServer(t *testing.T) {
+	c := &client{t: t, conn: dial(t, NewServer(2))}
+
+	dog := c.do(opSet, 0, setExtras(7, 0), "Dog", "Woof")
+	if dog.status != statusOK || dog.cas == 0 {
+		t.Fatalf("Expected Dog to be stored with a CAS token, but got: %+v", dog)
+	}
+	if resp := c.do(opAdd, 0, setExtras(0, 0), "Dog", "Bark"); resp.status != statusKeyExists {
+		t.Errorf("Expected add of an existing key to fail, but got: %+v", resp)
+	}
+	if resp := c.do(opReplace, 0, setExtras(0, 0), "Cat", "Meow"); resp.status != statusKeyNotFound {
+		t.Errorf("Expected replace of a missing key to fail, but got: %+v", resp)
+	}
+	if resp := c.do(opSet, dog.cas+1, setExtras(0, 0), "Dog", "Bark"); resp.status != statusKeyExists {
+		t.Errorf("Expected a set with a stale CAS token to fail, but got: %+v", resp)
+	}
+	bark := c.do(opSet, dog.cas, setExtras(0, 0), "Dog", "Bark")
+	if bark.status != statusOK || bark.cas == dog.cas {
+		t.Errorf("Expected a set with the current CAS token to succeed, but got: %+v", bark)
+	}
+	if resp := c.do(opDelete, dog.cas, nil, "Dog", ""); resp.status != statusKeyExists {
+		t.Errorf("Expected a delete with a stale CAS token to fail, but got: %+v", resp)
+	}
+
+	resp := c.do(opGet, 0, nil, "Dog", "")
+	if resp.status != statusOK || resp.value != "Bark" || resp.cas != bark.cas || binary.BigEndian.Uint32(resp.extras) != 0 {
+		t.Errorf("Expected Bark with flags 0, but got: %+v", resp)
+	}
+
+	// A past Unix time expires the item right away.
+	c.do(opSet, 0, setExtras(0, maxRelativeExpiration+1), "Cat", "Meow")
+	if resp := c.do(opGet, 0, nil, "Cat", ""); resp.status != statusKeyNotFound {
+		t.Errorf("Expected an expired item to be missing, but got: %+v", resp)
+	}
+
+	// Quiet commands only answer failures and hits; the noop flushes them.
+	c.send(opSetQ, 1, 0, setExtras(0, 0), "Cat", "Meow")
+	c.send(opGetQ, 2, 0, nil, "Soda", "")
+	c.send(opGetKQ, 3, 0, nil, "Cat", "")
+	c.send(opAddQ, 4, 0, setExtras(0, 0), "Cat", "Purr")
+	c.send(opNoop, 5, 0, nil, "", "")
+	for _, expected := range []response{
+		{opcode: opGetKQ, status: statusOK, opaque: 3, key: "Cat", value: "Meow"},
+		{opcode: opAddQ, status: statusKeyExists, opaque: 4, value: "Data exists for key."},
+		{opcode: opNoop, status: statusOK, opaque: 5},
+	} {
+		resp := c.receive()
+		if resp.opcode != expected.opcode || resp.status != expected.status || resp.opaque != expected.opaque ||
+			resp.key != expected.key || resp.value != expected.value {
+			t.Errorf("Expected %+v, but got: %+v", expected, resp)
+		}
+	}
+
+	// The cache holds two items, so Dog is evicted.
+	c.do(opSet, 0, setExtras(0, 0), "Soda", "Fizz")
+	if resp := c.do(opGet, 0, nil, "Dog", ""); resp.status != statusKeyNotFound {
+		t.Errorf("Expected Dog to be evicted, but got: %+v", resp)
+	}
+
+	c.send(opStat, 0, 0, nil, "", "")
+	stats := map[string]string{}
+	for resp := c.receive(); resp.key != ""; resp = c.receive() {
+		stats[resp.key] = resp.value
+	}
+	if stats["curr_items"] != "2" || stats["evictions"] != "1" || stats["get_hits"] != "2" || stats["version"] != Version {
+		t.Errorf("Unexpected stats: %v", stats)
+	}
+
+	if resp := c.do(opFlush, 0, nil, "", ""); resp.status != statusOK {
+		t.Errorf("Expected flush to succeed, but got: %+v", resp)
+	}
+	if resp := c.do(opGet, 0, nil, "Soda", ""); resp.status != statusKeyNotFound {
+		t.Errorf("Expected flush to remove Soda, but got: %+v", resp)
+	}
+
+	if resp := c.do(0x05, 0, nil, "Counter", ""); resp.status != statusUnknownCommand {
+		t.Errorf("Expected an unknown command status for increment, but got: %+v", resp)
+	}
+	if resp := c.do(opSet, 0, nil, "Dog", "Woof"); resp.status != statusInvalidArguments {
+		t.Errorf("Expected invalid arguments for a set without extras, but got: %+v", resp)
+	}
+
+	c.do(opQuit, 0, nil, "", "")
+	if _, err := c.conn.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("Expected quit to close the connection, but got: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package lru
+
+// MergeStrategy decides which entry Merge keeps when a key is cached in both
+// caches.
+type MergeStrategy int
+
+const (
+	// KeepExisting keeps the entry of the receiver.
+	KeepExisting MergeStrategy = iota
+	// OverwriteExisting replaces the entry of the receiver.
+	OverwriteExisting
+	// KeepNewer keeps whichever entry expires later. An entry which never
+	// expires is newer than one stored with a TTL; on a tie the entry of the
+	// receiver is kept.
+	KeepNewer
+)
+
+// Merge copies the live entries of other into the cache, from the least to
+// the most recently used one, so the entries most recently used in other end
+// up in front. A key cached in both counts as accessed in the cache, whichever
+// entry strategy keeps. The capacity of the cache still applies, so entries
+// may be evicted, and entries which cost more than its capacity are skipped.
+// Like values filled by a loader, merged entries are not written through.
+// other is left unchanged.
+func (c *Cache[K, V]) Merge(other *Cache[K, V], strategy MergeStrategy) {
+	if other == c {
+		return
+	}
+
+	// The entries of other are copied before c is locked, so two caches
+	// merged into each other at the same time do not deadlock.
+	nodes := other.liveNodes()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range nodes {
+		node := &nodes[i]
+		if node.cost > c.capacity {
+			continue
+		}
+
+		existing, _ := c.find(node.Key)
+		if existing == nil {
+			merged := c.newNode()
+			merged.copyFrom(node)
+			merged.cost = node.cost
+			c.insert(merged)
+			c.tag(merged, node.tags)
+			continue
+		}
+
+		c.promote(existing)
+		if existing.negative || strategy == OverwriteExisting || (strategy == KeepNewer && outlives(node, existing)) {
+			existing.copyFrom(node)
+			existing.cancelRevalidation()
+			existing.negative = false
+			c.tag(existing, node.tags)
+			c.reweigh(existing, node.cost)
+		}
+	}
+}
+
+// liveNodes returns copies of the live entries, from the least to the most
+// recently used one.
+func (c *Cache[K, V]) liveNodes() []Node[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var nodes []Node[K, V]
+	now := c.now()
+	for node := c.LinkedList.Tail.Left; node != c.LinkedList.Head; node = node.Left {
+		if !node.expired(now) && !node.negative {
+			nodes = append(nodes, *node)
+		}
+	}
+	return nodes
+}
+
+// copyFrom copies the key, value and lifetime of src into n.
+func (n *Node[K, V]) copyFrom(src *Node[K, V]) {
+	n.Key, n.Value = src.Key, src.Value
+	n.expiresAt, n.ttl = src.expiresAt, src.ttl
+	n.staleAt, n.stale = src.staleAt, src.stale
+}
+
+// outlives reports whether a expires after b.
+func outlives[K comparable, V any](a, b *Node[K, V]) bool {
+	da, db := a.deadline(), b.deadline()
+	if db.IsZero() {
+		return false
+	}
+	return da.IsZero() || da.After(db)
+}
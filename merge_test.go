@@ -0,0 +1,85 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy MergeStrategy
+		expected []int
+	}{
+		{"KeepExisting", KeepExisting, []int{20, 1, 3}},
+		{"OverwriteExisting", OverwriteExisting, []int{20, 10, 30}},
+		{"KeepNewer", KeepNewer, []int{20, 10, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := New[string, int](3)
+			clock := newTestClock(cache)
+			cache.SetWithTTL("Dog", 1, time.Second)
+			cache.Set("Soda", 3)
+			cache.Set("Tee", 4)
+
+			other := New[string, int](4)
+			other.now = clock.Now
+			other.SetWithTTL("Soda", 30, time.Minute)
+			other.SetWithTTL("Dog", 10, time.Minute)
+			other.Set("Cat", 20)
+
+			cache.Merge(other, tt.strategy)
+
+			// Entries of other are merged from the least recently used one, so
+			// Tee, only cached in the receiver, is evicted to make room.
+			expectedCacheState := []string{"Cat", "Dog", "Soda"}
+			actualCacheState := getCacheState(cache)
+			if !equalSlice(expectedCacheState, actualCacheState) {
+				t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+			}
+			if !equalSlice(tt.expected, cache.Values()) {
+				t.Errorf("Expected values: %v, but got: %v", tt.expected, cache.Values())
+			}
+			if other.Len() != 3 {
+				t.Errorf("Expected other to be left unchanged, but got Len: %d", other.Len())
+			}
+		})
+	}
+}
+
+func TestMergeSkipsExpired(t *testing.T) {
+	cache := New[string, int](4)
+	clock := newTestClock(cache)
+
+	other := New[string, int](4)
+	other.now = clock.Now
+	other.SetWithTTL("Dog", 1, time.Second)
+	other.SetWithCost("Cat", 2, 3)
+	clock.Advance(2 * time.Second)
+
+	cache.Merge(other, OverwriteExisting)
+	if cache.Contains("Dog") || !cache.Contains("Cat") || cache.Cost() != 3 {
+		t.Errorf("Expected only Cat with cost 3 to be merged, but got: %v", cache.Entries())
+	}
+}
+
+func TestSyncMerge(t *testing.T) {
+	a := NewSync[string, int](4)
+	b := NewSync[string, int](4)
+	a.Set("Dog", 1)
+	b.Set("Cat", 2)
+
+	done := make(chan struct{})
+	go func() {
+		b.Merge(a, KeepExisting)
+		close(done)
+	}()
+	a.Merge(b, KeepExisting)
+	<-done
+
+	if !a.Contains("Cat") || !b.Contains("Dog") {
+		t.Errorf("Expected both caches to hold each other's entries")
+	}
+}
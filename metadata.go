@@ -0,0 +1,74 @@
+package lru
+
+import "time"
+
+// EntryAge returns how long ago the entry stored under key was added, without
+// updating its position. The boolean reports whether the key was found.
+func (c *Cache[K, V]) EntryAge(key K) (time.Duration, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node, ok := c.Hash[key]
+	if !ok || !node.live(c.now()) {
+		return 0, false
+	}
+	return c.now().Sub(node.CreatedAt), true
+}
+
+// IdleTime returns how long ago the entry stored under key was last found by
+// Get or Check, or added if it never was, without updating its position. The
+// boolean reports whether the key was found.
+func (c *Cache[K, V]) IdleTime(key K) (time.Duration, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node, ok := c.Hash[key]
+	if !ok || !node.live(c.now()) {
+		return 0, false
+	}
+	return c.now().Sub(node.LastAccessedAt), true
+}
+
+// AccessCount returns how many times the entry stored under key was found by
+// Get or Check, without updating its position. The boolean reports whether the
+// key was found.
+func (c *Cache[K, V]) AccessCount(key K) (int64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node, ok := c.Hash[key]
+	if !ok || !node.live(c.now()) {
+		return 0, false
+	}
+	return node.AccessCount, true
+}
+
+// recordAccess updates the access metadata of node after a lookup found it.
+func (c *Cache[K, V]) recordAccess(node *Node[K, V]) {
+	node.LastAccessedAt = c.now()
+	node.AccessCount++
+}
+
+func (s *SyncCache[K, V]) EntryAge(key K) (time.Duration, bool) {
+	return s.cache.EntryAge(key)
+}
+
+func (s *SyncCache[K, V]) IdleTime(key K) (time.Duration, bool) {
+	return s.cache.IdleTime(key)
+}
+
+func (s *SyncCache[K, V]) AccessCount(key K) (int64, bool) {
+	return s.cache.AccessCount(key)
+}
+
+func (c *ShardedCache[K, V]) EntryAge(key K) (time.Duration, bool) {
+	return c.shard(key).EntryAge(key)
+}
+
+func (c *ShardedCache[K, V]) IdleTime(key K) (time.Duration, bool) {
+	return c.shard(key).IdleTime(key)
+}
+
+func (c *ShardedCache[K, V]) AccessCount(key K) (int64, bool) {
+	return c.shard(key).AccessCount(key)
+}
@@ -0,0 +1,39 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryMetadata(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	clock.Advance(time.Second)
+	cache.Set("Cat", 2)
+	clock.Advance(time.Second)
+
+	cache.Get("Dog")
+	cache.Check("Dog")
+	clock.Advance(time.Second)
+
+	// Overwriting keeps the creation time.
+	cache.Set("Dog", 10)
+
+	if age, ok := cache.EntryAge("Dog"); !ok || age != 3*time.Second {
+		t.Errorf("Expected Dog to be 3s old, but got: (%s, %t)", age, ok)
+	}
+	if idle, ok := cache.IdleTime("Dog"); !ok || idle != time.Second {
+		t.Errorf("Expected Dog to be idle for 1s, but got: (%s, %t)", idle, ok)
+	}
+	if idle, ok := cache.IdleTime("Cat"); !ok || idle != 2*time.Second {
+		t.Errorf("Expected Cat to be idle since it was added 2s ago, but got: (%s, %t)", idle, ok)
+	}
+	if count, ok := cache.AccessCount("Dog"); !ok || count != 2 {
+		t.Errorf("Expected 2 accesses to Dog, but got: (%d, %t)", count, ok)
+	}
+	if _, ok := cache.EntryAge("Soda"); ok {
+		t.Errorf("Expected no age for a missing entry")
+	}
+}
@@ -0,0 +1,141 @@
+package lru
+
+import (
+	"context"
+	"errors"
+)
+
+// GetMulti looks up every key in keys like Get and returns the values found.
+// Missing and expired keys are absent from the result. The hits are marked as
+// used in the order of keys, so the last key found ends up the most recently
+// used entry. Misses are not filled by a configured loader. The lock is taken
+// once for all keys.
+func (c *Cache[K, V]) GetMulti(keys []K) map[K]V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, ok := c.get(key); ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// GetMulti behaves like Cache.GetMulti.
+func (s *SyncCache[K, V]) GetMulti(keys []K) map[K]V {
+	return s.cache.GetMulti(keys)
+}
+
+// GetMulti behaves like Cache.GetMulti. Keys are grouped by shard, and the
+// shards are read one at a time in index order, each locked once.
+func (c *ShardedCache[K, V]) GetMulti(keys []K) map[K]V {
+	groups := make([][]K, len(c.shards))
+	for _, key := range keys {
+		i := hashKey(key) & c.mask
+		groups[i] = append(groups[i], key)
+	}
+
+	values := make(map[K]V, len(keys))
+	for i, group := range groups {
+		if group == nil {
+			continue
+		}
+		for key, value := range c.shards[i].GetMulti(group) {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// SetMulti stores every entry of entries like Set, in the iteration order of
+// entries, evicting as needed after each insertion. Entries whose write-through
+// fails are skipped, and their errors are returned joined together. The lock
+// is taken once for all entries; with WithWriteRateLimit, the tokens for all
+// of them are waited for before it is taken.
+func (c *Cache[K, V]) SetMulti(entries map[K]V) error {
+	for range entries {
+		if err := waitWrite(context.Background(), c.limiter); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+	for key, value := range entries {
+		if err := c.write(context.Background(), key, value, c.defaultTTL, 0, 1); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetMulti behaves like Cache.SetMulti.
+func (s *SyncCache[K, V]) SetMulti(entries map[K]V) error {
+	return s.cache.SetMulti(entries)
+}
+
+// SetMulti behaves like Cache.SetMulti. Entries are grouped by shard, and the
+// shards are written one at a time in index order, each locked once.
+func (c *ShardedCache[K, V]) SetMulti(entries map[K]V) error {
+	groups := make([]map[K]V, len(c.shards))
+	for key, value := range entries {
+		i := hashKey(key) & c.mask
+		if groups[i] == nil {
+			groups[i] = make(map[K]V)
+		}
+		groups[i][key] = value
+	}
+
+	var errs []error
+	for i, group := range groups {
+		if group != nil {
+			errs = append(errs, c.shards[i].SetMulti(group))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteMulti removes every key in keys like Delete and returns how many were
+// present. Like SetMulti, it waits for the rate limiter before taking the lock
+// once for all keys.
+func (c *Cache[K, V]) DeleteMulti(keys []K) (deleted int) {
+	for range keys {
+		waitWrite(context.Background(), c.limiter)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if c.deleteKey(key) {
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// DeleteMulti behaves like Cache.DeleteMulti.
+func (s *SyncCache[K, V]) DeleteMulti(keys []K) (deleted int) {
+	return s.cache.DeleteMulti(keys)
+}
+
+// DeleteMulti behaves like Cache.DeleteMulti. Keys are grouped by shard, so
+// every shard is locked at most once.
+func (c *ShardedCache[K, V]) DeleteMulti(keys []K) (deleted int) {
+	groups := make([][]K, len(c.shards))
+	for _, key := range keys {
+		i := hashKey(key) & c.mask
+		groups[i] = append(groups[i], key)
+	}
+
+	for i, group := range groups {
+		if group != nil {
+			deleted += c.shards[i].DeleteMulti(group)
+		}
+	}
+	return deleted
+}
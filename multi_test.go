@@ -0,0 +1,134 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetMulti(t *testing.T) {
+	cache := New[string, int](4)
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee"} {
+		cache.Set(e, i)
+	}
+
+	values := cache.GetMulti([]string{"Cat", "Terry", "Dog"})
+	if len(values) != 2 || values["Cat"] != 1 || values["Dog"] != 0 {
+		t.Errorf("Expected map[Cat:1 Dog:0], but got: %v", values)
+	}
+
+	// Hits are promoted in the order they were asked for.
+	expectedCacheState := []string{"Dog", "Cat", "Tee", "Soda"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, but got: %+v", stats)
+	}
+}
+
+func TestShardedGetMulti(t *testing.T) {
+	cache, err := NewSharded[string, int](64, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []string{"Dog", "Cat", "Soda", "Tee", "Terry", "Car"}
+	for i, key := range keys {
+		cache.Set(key, i)
+	}
+
+	values := cache.GetMulti(append(keys, "Missing"))
+	if len(values) != len(keys) {
+		t.Fatalf("Expected %d values, but got: %v", len(keys), values)
+	}
+	for i, key := range keys {
+		if values[key] != i {
+			t.Errorf("Expected %s=%d, but got: %d", key, i, values[key])
+		}
+	}
+}
+
+func TestSetMulti(t *testing.T) {
+	var evicted []string
+	cache := New(3, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	}))
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+
+	if err := cache.SetMulti(map[string]int{"Soda": 3, "Tee": 4}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Map iteration order is random, but only the oldest entry makes room.
+	expectedEvicted := []string{"Dog"}
+	if !equalSlice(expectedEvicted, evicted) {
+		t.Errorf("Expected evicted keys: %v, but got: %v", expectedEvicted, evicted)
+	}
+	if !cache.Contains("Cat") || !cache.Contains("Soda") || !cache.Contains("Tee") {
+		t.Errorf("Expected Cat, Soda and Tee to be cached, but got: %v", cache.Keys())
+	}
+}
+
+func TestSetMultiWriteThroughErrors(t *testing.T) {
+	errStoreDown := errors.New("store is down")
+	cache, err := NewSharded(16, 4, WithWriteThrough(func(key string, value int) error {
+		if key == "Cat" {
+			return errStoreDown
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cache.SetMulti(map[string]int{"Dog": 1, "Cat": 2, "Soda": 3})
+	if !errors.Is(err, errStoreDown) {
+		t.Errorf("Expected the write-through error, but got: %v", err)
+	}
+	if cache.Len() != 2 || cache.Contains("Cat") {
+		t.Errorf("Expected only the persisted entries to be cached, but got Len: %d", cache.Len())
+	}
+}
+
+func TestDeleteMulti(t *testing.T) {
+	var evicted []string
+	cache := NewSync(4, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	}))
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee"} {
+		cache.Set(e, i)
+	}
+
+	if deleted := cache.DeleteMulti([]string{"Cat", "Terry", "Tee"}); deleted != 2 {
+		t.Errorf("Expected 2 deleted entries, but got: %d", deleted)
+	}
+
+	expectedEvicted := []string{"Cat", "Tee"}
+	if !equalSlice(expectedEvicted, evicted) {
+		t.Errorf("Expected evicted keys: %v, but got: %v", expectedEvicted, evicted)
+	}
+	expectedKeys := []string{"Soda", "Dog"}
+	if !equalSlice(expectedKeys, cache.Keys()) {
+		t.Errorf("Expected keys: %v, but got: %v", expectedKeys, cache.Keys())
+	}
+}
+
+func TestShardedDeleteMulti(t *testing.T) {
+	cache, err := NewSharded[string, int](64, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{"Dog", "Cat", "Soda", "Tee", "Terry", "Car"}
+	for i, key := range keys {
+		cache.Set(key, i)
+	}
+
+	if deleted := cache.DeleteMulti(append(keys[:4], "Missing")); deleted != 4 {
+		t.Errorf("Expected 4 deleted entries, but got: %d", deleted)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries left, but got: %d", cache.Len())
+	}
+}
@@ -0,0 +1,104 @@
+package lru
+
+import "strings"
+
+// NamespaceSeparator separates the namespace from the key in the keys stored
+// by a NamespacedCache.
+const NamespaceSeparator = ":"
+
+// NamespacedCache is a view of a cache with string keys which prefixes every
+// key with a namespace, so several logical caches can share one physical
+// cache, its capacity and its recently used order. It implements Interface,
+// with Len, Clear and ForEach only seeing the entries of its namespace.
+type NamespacedCache[V any] struct {
+	cache  Interface[string, V]
+	prefix string
+}
+
+var _ Interface[string, string] = (*NamespacedCache[string])(nil)
+
+// WithNamespace returns a view of cache in which every key is stored as
+// ns + NamespaceSeparator + key. The view is safe for concurrent use if cache
+// is.
+func WithNamespace[V any](cache Interface[string, V], ns string) *NamespacedCache[V] {
+	return &NamespacedCache[V]{cache: cache, prefix: ns + NamespaceSeparator}
+}
+
+// InvalidateNamespace removes every entry of cache stored under namespace ns
+// and returns how many were removed.
+func InvalidateNamespace[V any](cache Interface[string, V], ns string) int {
+	return WithNamespace(cache, ns).Invalidate()
+}
+
+func (n *NamespacedCache[V]) Get(key string) (V, bool) {
+	return n.cache.Get(n.prefix + key)
+}
+
+func (n *NamespacedCache[V]) Set(key string, value V) error {
+	return n.cache.Set(n.prefix+key, value)
+}
+
+func (n *NamespacedCache[V]) Delete(key string) bool {
+	return n.cache.Delete(n.prefix + key)
+}
+
+func (n *NamespacedCache[V]) Peek(key string) (V, bool) {
+	return n.cache.Peek(n.prefix + key)
+}
+
+func (n *NamespacedCache[V]) Contains(key string) bool {
+	return n.cache.Contains(n.prefix + key)
+}
+
+// Len returns the number of entries in the namespace. It walks the whole
+// underlying cache.
+func (n *NamespacedCache[V]) Len() int {
+	count := 0
+	n.ForEach(func(string, V) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Cap returns the capacity of the underlying cache, which is shared by all
+// namespaces.
+func (n *NamespacedCache[V]) Cap() int {
+	return n.cache.Cap()
+}
+
+// Clear removes every entry of the namespace, leaving other namespaces alone.
+func (n *NamespacedCache[V]) Clear() {
+	n.Invalidate()
+}
+
+// Invalidate removes every entry of the namespace and returns how many were
+// removed.
+func (n *NamespacedCache[V]) Invalidate() int {
+	var keys []string
+	n.cache.ForEach(func(key string, _ V) bool {
+		if strings.HasPrefix(key, n.prefix) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	removed := 0
+	for _, key := range keys {
+		if n.cache.Delete(key) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// ForEach calls fn for every entry of the namespace, in the order of the
+// underlying cache, with the namespace stripped from the keys.
+func (n *NamespacedCache[V]) ForEach(fn func(key string, value V) bool) {
+	n.cache.ForEach(func(key string, value V) bool {
+		if !strings.HasPrefix(key, n.prefix) {
+			return true
+		}
+		return fn(strings.TrimPrefix(key, n.prefix), value)
+	})
+}
@@ -0,0 +1,57 @@
+package lru
+
+import "testing"
+
+func TestNamespacedCache(t *testing.T) {
+	cache := New[string, int](4)
+	users := WithNamespace[int](cache, "users")
+	products := WithNamespace[int](cache, "products")
+
+	users.Set("1", 10)
+	users.Set("2", 20)
+	products.Set("1", 100)
+
+	if value, found := users.Get("1"); !found || value != 10 {
+		t.Errorf("Expected (10, true), but got: (%d, %t)", value, found)
+	}
+	if value, found := products.Get("1"); !found || value != 100 {
+		t.Errorf("Expected (100, true), but got: (%d, %t)", value, found)
+	}
+	if value, found := cache.Peek("users:2"); !found || value != 20 {
+		t.Errorf("Expected the key to be stored with its namespace, but got: (%d, %t)", value, found)
+	}
+	if users.Len() != 2 || products.Len() != 1 || users.Cap() != 4 {
+		t.Errorf("Expected Len 2 and 1, but got: %d and %d", users.Len(), products.Len())
+	}
+
+	expectedKeys := []string{"1", "2"}
+	var actualKeys []string
+	users.ForEach(func(key string, _ int) bool {
+		actualKeys = append(actualKeys, key)
+		return true
+	})
+	if !equalSlice(expectedKeys, actualKeys) {
+		t.Errorf("Expected keys: %v, but got: %v", expectedKeys, actualKeys)
+	}
+
+	if removed := InvalidateNamespace[int](cache, "users"); removed != 2 {
+		t.Errorf("Expected 2 entries to be removed, but got: %d", removed)
+	}
+	if users.Contains("1") || !products.Contains("1") {
+		t.Errorf("Expected only the users namespace to be invalidated")
+	}
+}
+
+func TestNamespacedShardedCache(t *testing.T) {
+	cache, err := NewSharded[string, string](16, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := WithNamespace[string](cache, "sessions")
+
+	sessions.Set("abc", "Dog")
+	sessions.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("Expected Clear to remove the namespace entries, but got Len: %d", cache.Len())
+	}
+}
@@ -0,0 +1,67 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// errCachedNotFound is reported for a key whose "not found" result was cached
+// by WithNegativeCaching, so the loader is not called for it again.
+var errCachedNotFound = fmt.Errorf("%w (cached)", ErrNotFound)
+
+// WithNegativeCaching caches the "not found" results of the loader for ttl,
+// so repeated misses on a key the backing store does not have do not reach it
+// every time. A result is "not found" when the loader error matches
+// ErrNotFound or one of notFound. Until the negative entry expires, Get
+// reports a miss and Fetch an error matching ErrNotFound without calling the
+// loader; storing a value under the key replaces it. Negative entries take
+// room in the cache like any other entry and are hidden from lookups, but like
+// expired entries they show up with the zero value when iterating over the
+// cache.
+func WithNegativeCaching[K comparable, V any](ttl time.Duration, notFound ...error) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.negativeTTL = ttl
+		c.notFound = append([]error{ErrNotFound}, notFound...)
+	}
+}
+
+// shouldLoad reports whether a lookup which failed with err is filled by the
+// loader.
+func (c *Cache[K, V]) shouldLoad(err error) bool {
+	return err != nil && err != errCachedNotFound && c.loader != nil
+}
+
+// lockedStoreLoaded is storeLoaded holding the lock.
+func (c *Cache[K, V]) lockedStoreLoaded(key K, value V, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.storeLoaded(key, value, err)
+}
+
+// storeLoaded caches the result of a load: the value when the loader
+// succeeded and a negative entry when it reported a missing key and negative
+// caching is enabled.
+func (c *Cache[K, V]) storeLoaded(key K, value V, err error) {
+	if err == nil {
+		c.store(key, value)
+		return
+	}
+	if c.negativeTTL <= 0 || !c.isNotFound(err) {
+		return
+	}
+
+	var zero V
+	c.set(key, zero, c.negativeTTL, 0, 1)
+	c.Hash[key].negative = true
+}
+
+func (c *Cache[K, V]) isNotFound(err error) bool {
+	for _, target := range c.notFound {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithNegativeCaching(t *testing.T) {
+	errNoRows := errors.New("no rows")
+	calls := 0
+	cache := New(4,
+		WithLoader(func(key string) (int, error) {
+			calls++
+			switch key {
+			case "Dog":
+				return 0, ErrNotFound
+			case "Cat":
+				return 0, errNoRows
+			default:
+				return 0, errors.New("store is down")
+			}
+		}),
+		WithNegativeCaching[string, int](time.Second, errNoRows),
+	)
+	clock := newTestClock(cache)
+
+	if _, err := cache.Fetch("Dog"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, but got: %v", err)
+	}
+	if _, err := cache.Fetch("Cat"); !errors.Is(err, errNoRows) {
+		t.Fatalf("Expected the loader error, but got: %v", err)
+	}
+
+	// The not found results are served from the cache.
+	if _, err := cache.Fetch("Dog"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, but got: %v", err)
+	}
+	if _, found := cache.Get("Cat"); found {
+		t.Errorf("Expected a cached not found result to be a miss")
+	}
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Contains to hide negative entries")
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 loader calls, but got: %d", calls)
+	}
+
+	// Other errors are not cached.
+	cache.Get("Soda")
+	cache.Get("Soda")
+	if calls != 4 || cache.Len() != 2 {
+		t.Errorf("Expected failed loads not to be cached, but got %d calls and Len %d", calls, cache.Len())
+	}
+
+	// Setting a value replaces the negative entry.
+	cache.Set("Cat", 2)
+	if value, found := cache.Get("Cat"); !found || value != 2 {
+		t.Errorf("Expected (2, true), but got: (%d, %t)", value, found)
+	}
+
+	// Once it expires, the loader is asked again.
+	clock.Advance(2 * time.Second)
+	cache.Get("Dog")
+	if calls != 5 {
+		t.Errorf("Expected the loader to be called after the negative entry expired, but got: %d calls", calls)
+	}
+}
+
+func TestNegativeEntryCheckAndSetNX(t *testing.T) {
+	cache := NewSync(4,
+		WithLoader(func(key string) (string, error) {
+			return "", ErrNotFound
+		}),
+		WithNegativeCaching[string, string](time.Minute),
+	)
+
+	cache.Get("Dog")
+	cache.Get("Cat")
+	if !cache.SetNX("Dog", "Woof") {
+		t.Errorf("Expected SetNX to replace a negative entry")
+	}
+	cache.Check("Cat")
+	if !cache.Contains("Cat") || cache.Len() != 2 {
+		t.Errorf("Expected Check to turn the negative entry into a regular one, but got Len: %d", cache.Len())
+	}
+	if value, found := cache.Get("Dog"); !found || value != "Woof" {
+		t.Errorf("Expected (Woof, true), but got: (%q, %t)", value, found)
+	}
+}
@@ -0,0 +1,157 @@
+package lru
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Option configures a Cache created by New.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithCapacity sets the maximum number of entries, or total cost, the cache
+// can hold, overriding the capacity passed to New.
+func WithCapacity[K comparable, V any](n int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.capacity = n
+	}
+}
+
+// WithTTL makes entries stored without an explicit lifetime, by Set, SetNX,
+// Swap, SetWithCost, SetWithTags or a loader, expire once d has elapsed.
+// SetWithTTL and SetWithSWR are not affected.
+func WithTTL[K comparable, V any](d time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.defaultTTL = d
+	}
+}
+
+// WithSlidingTTL makes every successful Get restart the lifetime of entries
+// stored with SetWithTTL, so entries only expire once they stop being read.
+func WithSlidingTTL[K comparable, V any]() Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.slidingTTL = true
+	}
+}
+
+// WithEvictionPolicy selects which entry is evicted when the cache is full.
+// The default is PolicyLRU.
+func WithEvictionPolicy[K comparable, V any](p EvictionPolicy) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.evictionPolicy = p
+	}
+}
+
+// WithMaxBytes limits the estimated memory taken by the cache to n bytes, on
+// top of its capacity. The size of an entry is estimated as the size of its
+// list node, which holds the key and value, plus the length of keys and
+// values which are strings or byte slices. Entries are evicted
+// until a new entry fits, and entries larger than n are rejected with
+// ErrEntryTooLarge.
+func WithMaxBytes[K comparable, V any](n int64) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.stats.maxBytes = n
+	}
+}
+
+// WithOnEvict registers fn to be called whenever an entry leaves the cache,
+// whether it is pushed out by capacity, deleted, expired or cleared. fn runs
+// synchronously before the entry is unlinked. When the option is given more
+// than once, every fn is called, in the order the options were given.
+func WithOnEvict[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvict = chainHooks(c.onEvict, fn)
+	}
+}
+
+// WithOnFlush registers fn to be called by Drain for every live entry, so
+// entries can be written to a backing store on shutdown. Errors returned by fn
+// are collected and returned by Drain.
+func WithOnFlush[K comparable, V any](fn func(key K, value V) error) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onFlush = fn
+	}
+}
+
+// WithOnHit registers fn to be called by Get whenever it finds a live entry.
+// Like WithOnEvict, it adds to the hooks registered by earlier options.
+func WithOnHit[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onHit = chainHooks(c.onHit, fn)
+	}
+}
+
+// WithOnMiss registers fn to be called by Get whenever the key is missing or
+// its entry has expired. Like WithOnEvict, it adds to the hooks registered by
+// earlier options.
+func WithOnMiss[K comparable, V any](fn func(key K)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onMiss = chainHooks(c.onMiss, func(key K, _ struct{}) { fn(key) })
+	}
+}
+
+// chainHooks returns a hook calling prev, if set, and then fn.
+func chainHooks[K comparable, V any](prev, fn func(key K, value V)) func(key K, value V) {
+	if prev == nil {
+		return fn
+	}
+	return func(key K, value V) {
+		prev(key, value)
+		fn(key, value)
+	}
+}
+
+// WithExpiryChannel makes the cache send the key of every entry it removes
+// because it expired, whether found by a lookup or by DeleteExpired and the
+// janitor. Sends never block: when ch is not ready to receive, the key is
+// dropped, so ch should be buffered.
+func WithExpiryChannel[K comparable, V any](ch chan<- K) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.expired = ch
+	}
+}
+
+// WithLogger makes the cache log removals at debug level, resizes at info
+// level and loader failures at warn level.
+func WithLogger[K comparable, V any](logger *slog.Logger) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.logger = logger
+	}
+}
+
+// WithLoader makes Get fill misses by calling fn. Concurrent misses on the
+// same key share a single call to fn. Values are only cached when fn succeeds;
+// failures are logged and reported by Get as a miss.
+func WithLoader[K comparable, V any](fn func(key K) (V, error)) Option[K, V] {
+	return WithLoaderContext(func(_ context.Context, key K) (V, error) {
+		return fn(key)
+	})
+}
+
+// WithLoaderContext is WithLoader for a loader which takes the context passed
+// to GetContext, without its cancellation: a load shared by several callers
+// carries on when the caller that started it gives up. Get and background
+// revalidations pass context.Background().
+func WithLoaderContext[K comparable, V any](fn func(ctx context.Context, key K) (V, error)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.loader = fn
+	}
+}
+
+// WithWriteThrough makes every Set, SetWithTTL, SetNX and Swap call fn before
+// the cache is updated. When fn fails the cache is left unchanged, so it never
+// holds a value the backing store does not. Values filled by a loader are not
+// written back.
+func WithWriteThrough[K comparable, V any](fn func(key K, value V) error) Option[K, V] {
+	return WithWriteThroughContext(func(_ context.Context, key K, value V) error {
+		return fn(key, value)
+	})
+}
+
+// WithWriteThroughContext is WithWriteThrough for a function which takes the
+// context passed to SetContext. The other setters pass context.Background().
+func WithWriteThroughContext[K comparable, V any](fn func(ctx context.Context, key K, value V) error) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.writer = fn
+	}
+}
@@ -0,0 +1,92 @@
+// Package otel wraps lru caches so their operations are recorded as
+// OpenTelemetry spans.
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+const instrumentationName = "github.com/Hubert-Madej/go-lru-cache/otel"
+
+// Cache records a child span of the caller's context for every Get, Set and
+// Delete made through it, then delegates to the wrapped cache.
+type Cache[K comparable, V any] struct {
+	cache  lru.Interface[K, V]
+	tracer trace.Tracer
+}
+
+// Option configures a Cache created by New.
+type Option func(*config)
+
+type config struct {
+	tracer trace.Tracer
+}
+
+// WithTracer sets the tracer spans are started with. By default the tracer is
+// taken from the global tracer provider.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(cfg *config) {
+		cfg.tracer = tracer
+	}
+}
+
+// New wraps c so its operations are traced.
+func New[K comparable, V any](c lru.Interface[K, V], opts ...Option) *Cache[K, V] {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.tracer == nil {
+		cfg.tracer = otel.Tracer(instrumentationName)
+	}
+
+	return &Cache[K, V]{cache: c, tracer: cfg.tracer}
+}
+
+// Get looks key up in a span named "lru.get", tagged with cache.key and
+// cache.hit.
+func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, bool) {
+	_, span := c.tracer.Start(ctx, "lru.get", trace.WithAttributes(keyAttribute(key)))
+	defer span.End()
+
+	value, ok := c.cache.Get(key)
+	span.SetAttributes(attribute.Bool("cache.hit", ok))
+	return value, ok
+}
+
+// Set stores value under key in a span named "lru.set", tagged with cache.key.
+// Errors are recorded on the span.
+func (c *Cache[K, V]) Set(ctx context.Context, key K, value V) error {
+	_, span := c.tracer.Start(ctx, "lru.set", trace.WithAttributes(keyAttribute(key)))
+	defer span.End()
+
+	err := c.cache.Set(key, value)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// Delete removes key in a span named "lru.delete", tagged with cache.key and
+// cache.hit, which reports whether the key was present.
+func (c *Cache[K, V]) Delete(ctx context.Context, key K) bool {
+	_, span := c.tracer.Start(ctx, "lru.delete", trace.WithAttributes(keyAttribute(key)))
+	defer span.End()
+
+	ok := c.cache.Delete(key)
+	span.SetAttributes(attribute.Bool("cache.hit", ok))
+	return ok
+}
+
+func keyAttribute[K comparable](key K) attribute.KeyValue {
+	return attribute.String("cache.key", fmt.Sprint(key))
+}
@@ -0,0 +1,70 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	cache := New[string, int](lru.New[string, int](2), WithTracer(provider.Tracer("test")))
+
+	cache.Set(ctx, "Dog", 1)
+	cache.Get(ctx, "Dog")
+	cache.Get(ctx, "Cat")
+	cache.Delete(ctx, "Dog")
+	parent.End()
+
+	spans := recorder.Ended()
+	expected := []struct {
+		name string
+		key  string
+		hit  *bool
+	}{
+		{"lru.set", "Dog", nil},
+		{"lru.get", "Dog", boolPtr(true)},
+		{"lru.get", "Cat", boolPtr(false)},
+		{"lru.delete", "Dog", boolPtr(true)},
+	}
+	if len(spans) != len(expected)+1 {
+		t.Fatalf("Expected %d spans, but got: %d", len(expected)+1, len(spans))
+	}
+
+	for i, e := range expected {
+		span := spans[i]
+		if span.Name() != e.name {
+			t.Errorf("Expected span %d to be %s, but got: %s", i, e.name, span.Name())
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Expected span %s to be a child of the request span", span.Name())
+		}
+
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		if attrs["cache.key"].AsString() != e.key {
+			t.Errorf("Expected span %s to have cache.key %s, but got: %v", span.Name(), e.key, attrs["cache.key"])
+		}
+		hit, ok := attrs["cache.hit"]
+		if e.hit == nil && ok {
+			t.Errorf("Expected span %s not to have cache.hit, but got: %v", span.Name(), hit)
+		}
+		if e.hit != nil && (!ok || hit.AsBool() != *e.hit) {
+			t.Errorf("Expected span %s to have cache.hit %t, but got: %v", span.Name(), *e.hit, hit)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
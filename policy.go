@@ -0,0 +1,274 @@
+package lru
+
+import (
+	"container/list"
+	"fmt"
+	"time"
+)
+
+// EvictionPolicy chooses which entry a full cache evicts to make room. The
+// zero value is PolicyLRU. The cache keeps its entries in a list ordered from
+// the most to the least recently used one, so Keys, Oldest and friends behave
+// the same whatever the policy. The exceptions are PolicyFIFO and PolicyClock,
+// which never reorder the list and so keep it in insertion order.
+type EvictionPolicy struct {
+	kind       policyKind
+	ratio      float64
+	ghostRatio float64
+	k          int
+}
+
+type policyKind int
+
+const (
+	policyLRU policyKind = iota
+	policyLFU
+	policyFIFO
+	policyClock
+	policyTwoQueue
+	policyARC
+	policyWTinyLFU
+	policySLRU
+	policyLRUK
+)
+
+var (
+	// PolicyLRU evicts the least recently used entry.
+	PolicyLRU = EvictionPolicy{kind: policyLRU}
+
+	// PolicyLFU evicts the least frequently used entry. Entries used equally
+	// often are evicted from the least recently used one.
+	PolicyLFU = EvictionPolicy{kind: policyLFU}
+
+	// PolicyFIFO evicts the entry which was inserted first. Reading or
+	// overwriting an entry does not change its position.
+	PolicyFIFO = EvictionPolicy{kind: policyFIFO}
+
+	// PolicyClock approximates LRU with the CLOCK algorithm. Accesses only
+	// mark an entry as referenced instead of moving it; eviction sweeps the
+	// entries like a clock hand, clearing marks until it finds an entry which
+	// was not referenced since the last sweep.
+	PolicyClock = EvictionPolicy{kind: policyClock}
+)
+
+// String returns the common name of the policy, such as "LRU" or "2Q". The
+// name of PolicyLRUK includes k, as in "LRU-2".
+func (p EvictionPolicy) String() string {
+	switch p.kind {
+	case policyLFU:
+		return "LFU"
+	case policyFIFO:
+		return "FIFO"
+	case policyClock:
+		return "CLOCK"
+	case policyTwoQueue:
+		return "2Q"
+	case policyARC:
+		return "ARC"
+	case policyWTinyLFU:
+		return "W-TinyLFU"
+	case policySLRU:
+		return "SLRU"
+	case policyLRUK:
+		return fmt.Sprintf("LRU-%d", p.k)
+	default:
+		return "LRU"
+	}
+}
+
+// policy tracks the entries of a cache on behalf of an EvictionPolicy.
+type policy[K comparable, V any] interface {
+	// add is called once node has been inserted and the cache has room for
+	// it.
+	add(node *Node[K, V])
+
+	// access is called when node is read or overwritten. It reports whether
+	// node should also move to the front of the cache list.
+	access(node *Node[K, V]) bool
+
+	// remove is called when node leaves the cache.
+	remove(node *Node[K, V])
+
+	// victim returns the node to evict to make room for incoming, which is
+	// not linked yet and nil when the cache shrinks. The cache is never empty
+	// when it is called.
+	victim(incoming *Node[K, V]) *Node[K, V]
+
+	// clear forgets every node.
+	clear()
+}
+
+func newPolicy[K comparable, V any](p EvictionPolicy, c *Cache[K, V]) policy[K, V] {
+	if c.customPolicy != nil {
+		return &customPolicy[K, V]{policy: c.customPolicy, cache: c}
+	}
+
+	switch p.kind {
+	case policyLFU:
+		return newLFUPolicy[K, V]()
+	case policyFIFO:
+		return &fifoPolicy[K, V]{list: &c.LinkedList}
+	case policyClock:
+		return &clockPolicy[K, V]{list: &c.LinkedList}
+	case policyTwoQueue:
+		return newTwoQueuePolicy[K, V](&c.capacity, p.ratio, p.ghostRatio)
+	case policyARC:
+		return newARCPolicy[K, V](&c.capacity)
+	case policyWTinyLFU:
+		return newTinyLFUPolicy[K, V](&c.capacity)
+	case policySLRU:
+		return newSLRUPolicy[K, V](&c.capacity, p.ratio)
+	case policyLRUK:
+		return newLRUKPolicy[K, V](p.k, func() time.Time { return c.now() })
+	default:
+		return &lruPolicy[K, V]{list: &c.LinkedList}
+	}
+}
+
+// lruPolicy evicts from the tail of the cache list, which already holds the
+// entries in recently used order.
+type lruPolicy[K comparable, V any] struct {
+	list *LinkedList[K, V]
+}
+
+func (p *lruPolicy[K, V]) add(node *Node[K, V])         {}
+func (p *lruPolicy[K, V]) access(node *Node[K, V]) bool { return true }
+func (p *lruPolicy[K, V]) remove(node *Node[K, V])      {}
+func (p *lruPolicy[K, V]) clear()                       {}
+
+func (p *lruPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	return p.list.Tail.Left
+}
+
+// fifoPolicy evicts from the tail of the cache list like lruPolicy, but never
+// moves accessed nodes, so the tail is always the oldest insertion.
+type fifoPolicy[K comparable, V any] struct {
+	list *LinkedList[K, V]
+}
+
+func (p *fifoPolicy[K, V]) add(node *Node[K, V])         {}
+func (p *fifoPolicy[K, V]) access(node *Node[K, V]) bool { return false }
+func (p *fifoPolicy[K, V]) remove(node *Node[K, V])      {}
+func (p *fifoPolicy[K, V]) clear()                       {}
+
+func (p *fifoPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	return p.list.Tail.Left
+}
+
+// clockPolicy treats the cache list as a ring, closed through the head and
+// tail sentinels. The hand moves from the tail towards the head, so it visits
+// entries in insertion order.
+type clockPolicy[K comparable, V any] struct {
+	list *LinkedList[K, V]
+	hand *Node[K, V]
+}
+
+func (p *clockPolicy[K, V]) add(node *Node[K, V]) {
+	node.Referenced = false
+}
+
+func (p *clockPolicy[K, V]) access(node *Node[K, V]) bool {
+	node.Referenced = true
+	return false
+}
+
+func (p *clockPolicy[K, V]) remove(node *Node[K, V]) {
+	// The left neighbour of an unlinked node is still in the ring.
+	if p.hand == node {
+		p.hand = node.Left
+	}
+}
+
+func (p *clockPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	node := p.hand
+	for {
+		if node == nil || node == p.list.Head {
+			node = p.list.Tail.Left
+		}
+		if !node.Referenced {
+			p.hand = node.Left
+			return node
+		}
+
+		node.Referenced = false
+		node = node.Left
+	}
+}
+
+func (p *clockPolicy[K, V]) clear() {
+	p.hand = nil
+}
+
+// lfuPolicy keeps a list of frequency buckets in ascending order. Each bucket
+// holds the nodes used that many times, most recently used first, so every
+// operation is O(1).
+type lfuPolicy[K comparable, V any] struct {
+	buckets *list.List
+	entries map[*Node[K, V]]lfuEntry
+}
+
+type lfuBucket struct {
+	frequency int
+	nodes     *list.List
+}
+
+// lfuEntry locates a node: its bucket in lfuPolicy.buckets and its element
+// in that bucket.
+type lfuEntry struct {
+	bucket *list.Element
+	elem   *list.Element
+}
+
+func newLFUPolicy[K comparable, V any]() *lfuPolicy[K, V] {
+	return &lfuPolicy[K, V]{buckets: list.New(), entries: map[*Node[K, V]]lfuEntry{}}
+}
+
+func (p *lfuPolicy[K, V]) add(node *Node[K, V]) {
+	node.frequency = 1
+
+	first := p.buckets.Front()
+	if first == nil || first.Value.(*lfuBucket).frequency != 1 {
+		first = p.buckets.PushFront(&lfuBucket{frequency: 1, nodes: list.New()})
+	}
+	p.entries[node] = lfuEntry{bucket: first, elem: first.Value.(*lfuBucket).nodes.PushFront(node)}
+}
+
+func (p *lfuPolicy[K, V]) access(node *Node[K, V]) bool {
+	entry := p.entries[node]
+	node.frequency++
+
+	next := entry.bucket.Next()
+	if next == nil || next.Value.(*lfuBucket).frequency != node.frequency {
+		next = p.buckets.InsertAfter(&lfuBucket{frequency: node.frequency, nodes: list.New()}, entry.bucket)
+	}
+
+	p.unlink(entry)
+	p.entries[node] = lfuEntry{bucket: next, elem: next.Value.(*lfuBucket).nodes.PushFront(node)}
+	return true
+}
+
+func (p *lfuPolicy[K, V]) remove(node *Node[K, V]) {
+	if entry, ok := p.entries[node]; ok {
+		p.unlink(entry)
+		delete(p.entries, node)
+	}
+}
+
+func (p *lfuPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	return p.buckets.Front().Value.(*lfuBucket).nodes.Back().Value.(*Node[K, V])
+}
+
+func (p *lfuPolicy[K, V]) clear() {
+	p.buckets.Init()
+	p.entries = map[*Node[K, V]]lfuEntry{}
+}
+
+// unlink takes the node of entry out of its bucket, dropping the bucket once
+// it is empty.
+func (p *lfuPolicy[K, V]) unlink(entry lfuEntry) {
+	bucket := entry.bucket.Value.(*lfuBucket)
+	bucket.nodes.Remove(entry.elem)
+	if bucket.nodes.Len() == 0 {
+		p.buckets.Remove(entry.bucket)
+	}
+}
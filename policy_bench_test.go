@@ -0,0 +1,117 @@
+package lru
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// BenchmarkPolicyWriteHeavy overwrites cached entries three times as often as
+// it reads them.
+//
+// CLOCK was expected to beat LRU here, as it skips the move to the front on
+// every access. The measurements did not bear that out: single threaded and
+// through a SyncCache shared by goroutines, both policies land within a few
+// ns/op of each other, since the map lookup, the stats counters and the lock
+// cost far more than relinking an entry.
+func BenchmarkPolicyWriteHeavy(b *testing.B) {
+	keys := make([]string, benchCapacity)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("Element%d", i)
+	}
+
+	policies := []struct {
+		name   string
+		policy EvictionPolicy
+	}{
+		{"lru", PolicyLRU},
+		{"clock", PolicyClock},
+	}
+
+	for _, p := range policies {
+		b.Run(p.name, func(b *testing.B) {
+			cache := New(benchCapacity, WithEvictionPolicy[string, int](p.policy))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				key := keys[i%len(keys)]
+				if i%4 == 0 {
+					cache.Get(key)
+				} else {
+					cache.Set(key, i)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPolicyWriteHeavyParallel runs the load of BenchmarkPolicyWriteHeavy
+// from GOMAXPROCS goroutines sharing a SyncCache, so every move to the front
+// happens under the lock of the cache.
+func BenchmarkPolicyWriteHeavyParallel(b *testing.B) {
+	keys := make([]string, benchCapacity)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("Element%d", i)
+	}
+
+	policies := []struct {
+		name   string
+		policy EvictionPolicy
+	}{
+		{"lru", PolicyLRU},
+		{"clock", PolicyClock},
+	}
+
+	for _, p := range policies {
+		b.Run(p.name, func(b *testing.B) {
+			cache := NewSync(benchCapacity, WithEvictionPolicy[string, int](p.policy))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := rand.Int()
+				for pb.Next() {
+					key := keys[i%len(keys)]
+					if i%4 == 0 {
+						cache.Get(key)
+					} else {
+						cache.Set(key, i)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkPolicyZipf replays a Zipf distributed trace over ten times more
+// keys than the cache holds and reports the hit rate of each policy, filling
+// every miss with Set.
+func BenchmarkPolicyZipf(b *testing.B) {
+	policies := []struct {
+		name   string
+		policy EvictionPolicy
+	}{
+		{"lru", PolicyLRU},
+		{"wtinylfu", PolicyWTinyLFU},
+	}
+
+	for _, p := range policies {
+		b.Run(p.name, func(b *testing.B) {
+			cache := New(benchCapacity, WithEvictionPolicy[uint64, uint64](p.policy))
+			zipf := rand.NewZipf(rand.New(rand.NewSource(1)), 1.01, 1, benchCapacity*10)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				key := zipf.Uint64()
+				if _, ok := cache.Get(key); !ok {
+					cache.Set(key, key)
+				}
+			}
+
+			b.ReportMetric(cache.Stats().HitRate()*100, "hit%")
+		})
+	}
+}
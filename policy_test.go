@@ -0,0 +1,200 @@
+package lru
+
+import "testing"
+
+func TestPolicyLFU(t *testing.T) {
+	cache := New(3, WithEvictionPolicy[string, int](PolicyLFU))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+
+	// Dog is read twice and Cat once, Soda is never read.
+	cache.Get("Dog")
+	cache.Get("Dog")
+	cache.Get("Cat")
+
+	cache.Set("Tee", 4)
+	if cache.Contains("Soda") {
+		t.Errorf("Expected the least frequently used entry to be evicted")
+	}
+
+	// Tee and Cat are both below Dog; Tee was used less often.
+	cache.Set("Car", 5)
+	if cache.Contains("Tee") {
+		t.Errorf("Expected the new entry with a single use to be evicted")
+	}
+
+	for _, key := range []string{"Dog", "Cat", "Car"} {
+		if !cache.Contains(key) {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+	if frequency := cache.Hash["Dog"].frequency; frequency != 3 {
+		t.Errorf("Expected Dog frequency 3, but got: %d", frequency)
+	}
+}
+
+func TestPolicyLFUTieBreak(t *testing.T) {
+	cache := New(3, WithEvictionPolicy[string, int](PolicyLFU))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+	cache.Get("Cat")
+	cache.Get("Dog")
+
+	// Dog and Cat have the same frequency, Cat was used longer ago. Soda goes
+	// first, then Cat.
+	cache.Set("Tee", 4)
+	cache.Get("Tee")
+	cache.Set("Car", 5)
+
+	expectedCacheState := []string{"Car", "Tee", "Dog"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestPolicyLFUScan(t *testing.T) {
+	cache := New(10, WithEvictionPolicy[int, int](PolicyLFU))
+
+	cache.Set(-1, -1)
+	for i := 0; i < 5; i++ {
+		cache.Get(-1)
+	}
+
+	// A scan of entries used once must not push out the frequently used one.
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+
+	if !cache.Contains(-1) {
+		t.Errorf("Expected frequently used entry to survive a scan")
+	}
+	if cache.Len() != 10 {
+		t.Errorf("Expected Len 10, but got: %d", cache.Len())
+	}
+}
+
+func TestPolicyLFUDeleteAndClear(t *testing.T) {
+	cache := New(2, WithEvictionPolicy[string, int](PolicyLFU))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Get("Cat")
+	cache.Delete("Dog")
+	cache.Set("Soda", 3)
+	cache.Set("Tee", 4)
+
+	if !cache.Contains("Cat") || cache.Contains("Soda") {
+		t.Errorf("Expected Soda to be evicted after Dog was deleted")
+	}
+
+	cache.Clear()
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Get("Dog")
+	cache.Set("Soda", 3)
+
+	expectedCacheState := []string{"Soda", "Dog"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestPolicyFIFO(t *testing.T) {
+	cache := New(3, WithEvictionPolicy[string, int](PolicyFIFO))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+
+	// Neither reads nor updates save the oldest entry.
+	cache.Get("Dog")
+	cache.Set("Dog", 10)
+	cache.Check("Dog")
+
+	cache.Set("Tee", 4)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected the first inserted entry to be evicted despite the hit")
+	}
+
+	expectedCacheState := []string{"Tee", "Soda", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	cache.Get("Cat")
+	cache.Set("Car", 5)
+	if cache.Contains("Cat") {
+		t.Errorf("Expected Cat to be evicted next")
+	}
+}
+
+func TestPolicyClock(t *testing.T) {
+	cache := New(3, WithEvictionPolicy[string, int](PolicyClock))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+
+	// Dog is referenced, so the hand clears its mark and moves on to Cat.
+	cache.Get("Dog")
+	cache.Set("Tee", 4)
+
+	if cache.Contains("Cat") || !cache.Contains("Dog") {
+		t.Errorf("Expected the first unreferenced entry to be evicted")
+	}
+	if cache.Hash["Dog"].Referenced {
+		t.Errorf("Expected the sweep to clear the referenced mark")
+	}
+
+	// Hits do not move entries.
+	expectedCacheState := []string{"Tee", "Soda", "Dog"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	// The hand continues from where it stopped: Soda is next.
+	cache.Set("Car", 5)
+	if cache.Contains("Soda") {
+		t.Errorf("Expected Soda to be evicted next")
+	}
+
+	// With every entry referenced the hand goes full circle and evicts the
+	// entry it started at.
+	for _, key := range []string{"Dog", "Tee", "Car"} {
+		cache.Get(key)
+	}
+	cache.Set("Terry", 6)
+	expectedCacheState = []string{"Terry", "Car", "Dog"}
+	actualCacheState = getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestPolicyClockDeleteAtHand(t *testing.T) {
+	cache := New(3, WithEvictionPolicy[string, int](PolicyClock))
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+	cache.Set("Tee", 4)
+
+	// The hand now rests on Cat; deleting it must not strand the hand.
+	cache.Delete("Cat")
+	cache.Set("Car", 5)
+	cache.Set("Terry", 6)
+
+	expectedCacheState := []string{"Terry", "Car", "Tee"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
@@ -0,0 +1,27 @@
+package lru
+
+// newNode returns an empty node, reusing the node of a removed entry when one
+// is available.
+func (c *Cache[K, V]) newNode() *Node[K, V] {
+	if c.noPool {
+		return &Node[K, V]{}
+	}
+	if node, ok := c.nodes.Get().(*Node[K, V]); ok {
+		return node
+	}
+	return &Node[K, V]{}
+}
+
+// releaseNode makes the node of a removed entry available to newNode. Nodes
+// with a background revalidation whose result was not applied yet are still
+// referenced by it, even if the entry was overwritten since, and are left to
+// the garbage collector. Nodes handed out by the exported Remove are
+// never released, since the caller may keep using them.
+func (c *Cache[K, V]) releaseNode(node *Node[K, V]) {
+	if c.noPool || node.refreshes > 0 {
+		return
+	}
+
+	*node = Node[K, V]{}
+	c.nodes.Put(node)
+}
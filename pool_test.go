@@ -0,0 +1,113 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNodePool(t *testing.T) {
+	cache := New[string, int](2)
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	evicted := cache.Hash["Dog"]
+	deleted := cache.Hash["Cat"]
+
+	cache.Set("Soda", 3)
+	cache.Delete("Cat")
+
+	// Nodes of removed entries are cleared before they are pooled, so they
+	// keep nothing alive.
+	for _, node := range []*Node[string, int]{evicted, deleted} {
+		if node.Key != "" || node.Value != 0 || node.Left != nil || node.Right != nil {
+			t.Errorf("Expected pooled node to be cleared, but got: %+v", node)
+		}
+	}
+
+	// Recycled nodes start out empty.
+	cache.Set("Tee", 4)
+	cache.Check("Car")
+	if value, _ := cache.Get("Car"); value != 0 {
+		t.Errorf("Expected a recycled node to have no value, but got: %d", value)
+	}
+	if value, _ := cache.Get("Tee"); value != 4 {
+		t.Errorf("Expected (4, true), but got: %d", value)
+	}
+}
+
+func TestNodePoolSkipsRemove(t *testing.T) {
+	cache := New[string, int](2)
+	cache.Set("Dog", 1)
+
+	node := cache.Remove(cache.Hash["Dog"])
+	if node.Key != "Dog" || node.Value != 1 {
+		t.Errorf("Expected Remove to hand back an intact node, but got: %+v", node)
+	}
+}
+
+func TestNodePoolSkipsRevalidating(t *testing.T) {
+	release := make(chan struct{})
+	cache := New(2, WithLoader(func(key string) (int, error) {
+		<-release
+		return 2, nil
+	}))
+	clock := newTestClock(cache)
+
+	cache.SetWithSWR("Dog", 1, time.Second, time.Second)
+	clock.Advance(1500 * time.Millisecond)
+	cache.Get("Dog")
+
+	node := cache.Hash["Dog"]
+	cache.Delete("Dog")
+	close(release)
+
+	if node.Key != "Dog" {
+		t.Errorf("Expected a node waiting for revalidation not to be pooled")
+	}
+
+	// The late result must not resurrect the deleted entry.
+	waitFor(t, func() bool {
+		return cache.hasRefreshed.Load()
+	})
+	if cache.Contains("Dog") {
+		t.Errorf("Expected the deleted entry to stay deleted")
+	}
+	cache.get("Cat")
+	if cache.Len() != 0 {
+		t.Errorf("Expected an empty cache, but got Len: %d", cache.Len())
+	}
+}
+
+func TestNodePoolSkipsOverwrittenRevalidating(t *testing.T) {
+	release := make(chan struct{})
+	cache := New(2, WithLoader(func(key string) (int, error) {
+		<-release
+		return 2, nil
+	}))
+	clock := newTestClock(cache)
+
+	cache.SetWithSWR("Dog", 1, time.Second, time.Second)
+	clock.Advance(1500 * time.Millisecond)
+	cache.Get("Dog")
+
+	// Overwriting the entry drops the reload, but the reload still holds
+	// the node, so it must not be recycled for Cat.
+	node := cache.Hash["Dog"]
+	cache.Set("Dog", 5)
+	cache.Delete("Dog")
+	cache.Set("Cat", 3)
+	if cache.Hash["Cat"] == node || node.Key != "Dog" {
+		t.Fatalf("Expected a node with a reload in flight not to be pooled")
+	}
+
+	close(release)
+	waitFor(t, func() bool {
+		return cache.hasRefreshed.Load()
+	})
+	if value, found := cache.Get("Cat"); !found || value != 3 {
+		t.Errorf("Expected (3, true), but got: (%d, %t)", value, found)
+	}
+	if cache.Contains("Dog") {
+		t.Errorf("Expected the deleted entry to stay deleted")
+	}
+}
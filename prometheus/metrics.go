@@ -0,0 +1,65 @@
+// Package prometheus exports lru cache statistics as Prometheus metrics.
+package prometheus
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+// Source is the part of a cache the metrics are read from. It is satisfied by
+// lru.Cache, lru.SyncCache and lru.ShardedCache. Metrics are collected from
+// the scraping goroutine, so caches shared this way should be thread-safe.
+type Source interface {
+	Stats() lru.Stats
+	Len() int
+}
+
+// RegisterMetrics registers collectors for the hits, misses, evictions and
+// current size of c with reg, labelled with cache=name. Values are read from
+// the cache on every scrape. When one of them cannot be registered, those
+// registered before it are unregistered again, so reg is left as it was.
+func RegisterMetrics(c Source, name string, reg prom.Registerer) error {
+	labels := prom.Labels{"cache": name}
+
+	collectors := []prom.Collector{
+		prom.NewCounterFunc(prom.CounterOpts{
+			Name:        "lru_cache_hits_total",
+			Help:        "Number of Get calls that found a live entry.",
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(c.Stats().Hits)
+		}),
+		prom.NewCounterFunc(prom.CounterOpts{
+			Name:        "lru_cache_misses_total",
+			Help:        "Number of Get calls that did not find a live entry.",
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(c.Stats().Misses)
+		}),
+		prom.NewCounterFunc(prom.CounterOpts{
+			Name:        "lru_cache_evictions_total",
+			Help:        "Number of entries dropped to stay within capacity.",
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(c.Stats().Evictions)
+		}),
+		prom.NewGaugeFunc(prom.GaugeOpts{
+			Name:        "lru_cache_size",
+			Help:        "Number of entries currently held by the cache.",
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(c.Len())
+		}),
+	}
+
+	for i, collector := range collectors {
+		if err := reg.Register(collector); err != nil {
+			for _, registered := range collectors[:i] {
+				reg.Unregister(registered)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package prometheus
+
+import (
+	"strings"
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+func TestRegisterMetrics(t *testing.T) {
+	cache := lru.NewSync[string, int](2)
+	reg := prom.NewRegistry()
+
+	if err := RegisterMetrics(cache, "users", reg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+	cache.Get("Soda")
+	cache.Get("Dog")
+
+	expected := `
+# HELP lru_cache_evictions_total Number of entries dropped to stay within capacity.
+# TYPE lru_cache_evictions_total counter
+lru_cache_evictions_total{cache="users"} 1
+# HELP lru_cache_hits_total Number of Get calls that found a live entry.
+# TYPE lru_cache_hits_total counter
+lru_cache_hits_total{cache="users"} 1
+# HELP lru_cache_misses_total Number of Get calls that did not find a live entry.
+# TYPE lru_cache_misses_total counter
+lru_cache_misses_total{cache="users"} 1
+# HELP lru_cache_size Number of entries currently held by the cache.
+# TYPE lru_cache_size gauge
+lru_cache_size{cache="users"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRegisterMetricsTwice(t *testing.T) {
+	cache := lru.NewSync[string, int](2)
+	reg := prom.NewRegistry()
+
+	if err := RegisterMetrics(cache, "users", reg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := RegisterMetrics(cache, "users", reg); err == nil {
+		t.Errorf("Expected duplicate registration to fail")
+	}
+	if err := RegisterMetrics(cache, "products", reg); err != nil {
+		t.Errorf("Expected registration under a different name to succeed, but got: %v", err)
+	}
+}
+
+func TestRegisterMetricsConflict(t *testing.T) {
+	cache := lru.NewSync[string, int](2)
+	reg := prom.NewRegistry()
+	reg.MustRegister(prom.NewCounter(prom.CounterOpts{
+		Name:        "lru_cache_evictions_total",
+		Help:        "Evictions counted by another library.",
+		ConstLabels: prom.Labels{"cache": "users"},
+	}))
+
+	if err := RegisterMetrics(cache, "users", reg); err == nil {
+		t.Fatalf("Expected registration to fail on the existing evictions counter")
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(families) != 1 || families[0].GetName() != "lru_cache_evictions_total" {
+		var names []string
+		for _, family := range families {
+			names = append(names, family.GetName())
+		}
+		t.Errorf("Expected only the existing counter to stay registered, but got: %v", names)
+	}
+}
@@ -0,0 +1,173 @@
+// Package proto serves an lru cache with string keys and byte values over
+// gRPC. The service is defined in cache.proto; cache.pb.go and
+// cache_grpc.pb.go are generated from it.
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative cache.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+// eventBuffer is the number of events queued for each Stream call. Events for
+// a stream whose queue is full are dropped rather than blocking the cache.
+const eventBuffer = 64
+
+// Server implements CacheServiceServer on top of a Cache.
+type Server struct {
+	UnimplementedCacheServiceServer
+
+	cache *lru.Cache[string, []byte]
+
+	subsMu sync.Mutex
+	subs   map[chan *CacheEvent]struct{}
+}
+
+// NewServer creates a Server for a new cache which holds at most capacity
+// entries. The cache is created with opts. Hooks set with WithOnHit,
+// WithOnMiss and WithOnEvict keep running, followed by the ones feeding
+// Stream.
+func NewServer(capacity int, opts ...lru.Option[string, []byte]) *Server {
+	s := &Server{subs: map[chan *CacheEvent]struct{}{}}
+
+	opts = append(opts[:len(opts):len(opts)],
+		lru.WithOnHit(func(key string, _ []byte) {
+			s.publish(CacheEvent_TYPE_HIT, key)
+		}),
+		lru.WithOnMiss[string, []byte](func(key string) {
+			s.publish(CacheEvent_TYPE_MISS, key)
+		}),
+		lru.WithOnEvict(func(key string, _ []byte) {
+			s.publish(CacheEvent_TYPE_EVICT, key)
+		}),
+	)
+	s.cache = lru.New(capacity, opts...)
+	return s
+}
+
+func (s *Server) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	value, err := s.cache.Fetch(req.Key)
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &GetResponse{Value: value}, nil
+}
+
+func (s *Server) Set(ctx context.Context, req *SetRequest) (*SetResponse, error) {
+	if req.TtlMillis < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_millis must not be negative")
+	}
+
+	var err error
+	if req.TtlMillis == 0 {
+		err = s.cache.Set(req.Key, req.Value)
+	} else {
+		err = s.cache.SetWithTTL(req.Key, req.Value, time.Duration(req.TtlMillis)*time.Millisecond)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &SetResponse{}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	return &DeleteResponse{Deleted: s.cache.Delete(req.Key)}, nil
+}
+
+func (s *Server) Stats(ctx context.Context, req *StatsRequest) (*StatsResponse, error) {
+	stats := s.cache.Stats()
+	return &StatsResponse{
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		Evictions:  stats.Evictions,
+		Insertions: stats.Insertions,
+		Len:        int64(s.cache.Len()),
+		Cap:        int64(s.cache.Cap()),
+	}, nil
+}
+
+// Stream sends the hits, misses and evictions of the cache until the call is
+// cancelled.
+func (s *Server) Stream(req *StreamRequest, stream CacheService_StreamServer) error {
+	events := s.subscribe()
+	defer s.unsubscribe(events)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) subscribe() chan *CacheEvent {
+	events := make(chan *CacheEvent, eventBuffer)
+
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	s.subs[events] = struct{}{}
+	return events
+}
+
+func (s *Server) unsubscribe(events chan *CacheEvent) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	delete(s.subs, events)
+}
+
+// publish queues an event for every Stream call. It runs from the cache hooks
+// with the cache locked, so it never blocks.
+func (s *Server) publish(typ CacheEvent_Type, key string) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	for events := range s.subs {
+		select {
+		case events <- &CacheEvent{Type: typ, Key: key}:
+		default:
+		}
+	}
+}
+
+// toStatus maps a cache error to a gRPC status error.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, lru.ErrNotFound), errors.Is(err, lru.ErrExpired):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, lru.ErrCacheFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor which turns a NOT_FOUND
+// status into an error matching lru.ErrNotFound, so callers can detect misses
+// with errors.Is as they would for a local cache. The status code stays
+// available through status.Code.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("%w: %w", lru.ErrNotFound, err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,165 @@
+package proto
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+// dial starts server on an in-memory listener and returns a client for it.
+func dial(t *testing.T, server *Server) CacheServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterCacheServiceServer(srv, server)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewCacheServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	client := dial(t, NewServer(2))
+	ctx := context.Background()
+
+	for _, key := range []string{"Dog", "Cat", "Soda"} {
+		if _, err := client.Set(ctx, &SetRequest{Key: key, Value: []byte(key + "!")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := client.Get(ctx, &GetRequest{Key: "Soda"})
+	if err != nil || string(resp.GetValue()) != "Soda!" {
+		t.Errorf("Expected Soda!, but got: %q, %v", resp.GetValue(), err)
+	}
+
+	_, err = client.Get(ctx, &GetRequest{Key: "Dog"})
+	if !errors.Is(err, lru.ErrNotFound) || status.Code(err) != codes.NotFound {
+		t.Errorf("Expected an evicted entry to match lru.ErrNotFound and NOT_FOUND, but got: %v", err)
+	}
+
+	if resp, err := client.Delete(ctx, &DeleteRequest{Key: "Cat"}); err != nil || !resp.GetDeleted() {
+		t.Errorf("Expected Cat to be deleted, but got: %v, %v", resp, err)
+	}
+
+	if _, err := client.Set(ctx, &SetRequest{Key: "Dog", TtlMillis: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a negative TTL, but got: %v", err)
+	}
+
+	stats, err := client.Stats(ctx, &StatsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 || stats.Insertions != 3 || stats.Len != 1 || stats.Cap != 2 {
+		t.Errorf("Unexpected stats: %v", stats)
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	server := NewServer(1)
+	client := dial(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Stream(ctx, &StreamRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The stream is registered asynchronously, so keep missing a key until
+	// the first event arrives.
+	received := make(chan *CacheEvent)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- event
+		}
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for subscribed := false; !subscribed; {
+		client.Get(ctx, &GetRequest{Key: "Probe"})
+		select {
+		case event := <-received:
+			subscribed = event.Type == CacheEvent_TYPE_MISS
+		case <-time.After(10 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("Expected the stream to deliver events")
+		}
+	}
+	for drained := false; !drained; {
+		select {
+		case <-received:
+		case <-time.After(50 * time.Millisecond):
+			drained = true
+		}
+	}
+
+	client.Set(ctx, &SetRequest{Key: "Dog", Value: []byte("Woof")})
+	client.Get(ctx, &GetRequest{Key: "Dog"})
+	client.Set(ctx, &SetRequest{Key: "Cat", Value: []byte("Meow")})
+	client.Get(ctx, &GetRequest{Key: "Dog"})
+
+	expected := []*CacheEvent{
+		{Type: CacheEvent_TYPE_HIT, Key: "Dog"},
+		{Type: CacheEvent_TYPE_EVICT, Key: "Dog"},
+		{Type: CacheEvent_TYPE_MISS, Key: "Dog"},
+	}
+	for _, want := range expected {
+		select {
+		case event := <-received:
+			if event.Type != want.Type || event.Key != want.Key {
+				t.Errorf("Expected event %v, but got: %v", want, event)
+			}
+		case <-deadline:
+			t.Fatalf("Expected event %v, but got none", want)
+		}
+	}
+}
+
+func TestServerKeepsHooks(t *testing.T) {
+	var evicted []string
+	server := NewServer(1, lru.WithOnEvict(func(key string, _ []byte) {
+		evicted = append(evicted, key)
+	}))
+	client := dial(t, server)
+	ctx := context.Background()
+
+	for _, key := range []string{"Dog", "Cat"} {
+		if _, err := client.Set(ctx, &SetRequest{Key: key, Value: []byte(key)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(evicted) != 1 || evicted[0] != "Dog" {
+		t.Errorf("Expected the OnEvict hook passed to NewServer to see Dog evicted, but got: %v", evicted)
+	}
+}
@@ -0,0 +1,38 @@
+package lru
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// WithWriteRateLimit limits writes to rps per second with bursts of up to
+// burst writes. Set, its variants, Swap and Delete block until the limiter
+// allows them; with SetContext the wait ends early with ctx.Err() once ctx is
+// done. Reads, values filled by a loader and Check are not limited. Writers wait
+// before taking the lock of the cache, so a throttled writer never blocks
+// readers, whether the cache is a Cache or a SyncCache. A ShardedCache splits
+// the rate and the burst over its shards.
+func WithWriteRateLimit[K comparable, V any](rps float64, burst int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.limiter = rate.NewLimiter(rate.Limit(rps), max(burst, 1))
+	}
+}
+
+// waitWrite blocks until limiter allows one more write. A nil limiter never
+// blocks.
+func waitWrite(ctx context.Context, limiter *rate.Limiter) error {
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+// cloneLimiter returns a limiter with the same settings as limiter, which
+// starts with a full burst.
+func cloneLimiter(limiter *rate.Limiter) *rate.Limiter {
+	if limiter == nil {
+		return nil
+	}
+	return rate.NewLimiter(limiter.Limit(), limiter.Burst())
+}
@@ -0,0 +1,71 @@
+package lru
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithWriteRateLimit(t *testing.T) {
+	cache := New(4, WithWriteRateLimit[string, int](20, 2))
+
+	// The burst is available right away.
+	start := time.Now()
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	if elapsed := time.Since(start); elapsed > 25*time.Millisecond {
+		t.Errorf("Expected the burst not to wait, but took: %s", elapsed)
+	}
+
+	// A context which is done before a token frees up fails the write.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := cache.SetContext(ctx, "Soda", 3); err == nil {
+		t.Errorf("Expected the throttled write to fail with its context")
+	}
+	if cache.Contains("Soda") {
+		t.Errorf("Expected a throttled write not to be cached")
+	}
+
+	// Further writes wait for the limiter, reads do not.
+	start = time.Now()
+	cache.Get("Dog")
+	cache.Delete("Dog")
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("Expected Delete to wait for the limiter, but took: %s", elapsed)
+	}
+}
+
+func TestWriteRateLimitDoesNotBlockReads(t *testing.T) {
+	caches := map[string]Interface[string, int]{
+		"cache": New(4, WithWriteRateLimit[string, int](10, 1)),
+		"sync":  NewSync(4, WithWriteRateLimit[string, int](10, 1)),
+	}
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			cache.Set("Dog", 1)
+
+			done := make(chan struct{})
+			go func() {
+				cache.Set("Cat", 2)
+				close(done)
+			}()
+			// Give the writer time to start waiting for the limiter.
+			time.Sleep(10 * time.Millisecond)
+
+			// The waiting writer does not hold the lock, so reads go through.
+			start := time.Now()
+			if _, found := cache.Get("Dog"); !found {
+				t.Errorf("Expected Dog to be cached")
+			}
+			if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+				t.Errorf("Expected the read not to wait for the writer, but took: %s", elapsed)
+			}
+
+			<-done
+			if !cache.Contains("Cat") {
+				t.Errorf("Expected the throttled write to be cached eventually")
+			}
+		})
+	}
+}
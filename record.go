@@ -0,0 +1,152 @@
+package lru
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Operations written to an access log by StartRecording.
+const (
+	opGet    = "get"
+	opSet    = "set"
+	opDelete = "delete"
+)
+
+// accessRecord is a single line of an access log. TS is the time of the call
+// in Unix nanoseconds.
+type accessRecord[K any] struct {
+	Op  string `json:"op"`
+	Key K      `json:"key"`
+	TS  int64  `json:"ts"`
+}
+
+// recorder writes access records as newline-delimited JSON. It has its own
+// lock since the shards of a ShardedCache share one.
+type recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+func (r *recorder) record(op string, key any, ts int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Stop at the first failed write rather than leaving holes in the log.
+	if r.err == nil {
+		r.err = r.enc.Encode(accessRecord[any]{Op: op, Key: key, TS: ts})
+	}
+}
+
+// StartRecording writes a line of JSON such as
+//
+//	{"op":"get","key":"Dog","ts":1700000000000000000}
+//
+// to w for every Get, Set and Delete call from now on, replacing any previous
+// recording. Fetch, GetContext and other lookups are logged as gets and the
+// other setters, such as SetWithTTL, as sets. Values are not recorded. The log
+// can be fed to Replay to simulate other cache settings offline.
+func (c *Cache[K, V]) StartRecording(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.recorder = &recorder{enc: json.NewEncoder(w)}
+}
+
+// StopRecording stops the recording started by StartRecording and returns
+// the first error writing it, after which nothing more was written.
+func (c *Cache[K, V]) StopRecording() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := c.recorder
+	c.recorder = nil
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// recordOp logs op on key if a recording is running.
+func (c *Cache[K, V]) recordOp(op string, key K) {
+	if c.recorder != nil {
+		c.recorder.record(op, key, c.now().UnixNano())
+	}
+}
+
+// Replay runs the operations of an access log written by StartRecording
+// against c and returns the usage counters they added up to. Sets store the
+// zero value, since values are not recorded. Pass a new cache with the
+// capacity and options to simulate.
+func Replay[K comparable, V any](r io.Reader, c *Cache[K, V]) (Stats, error) {
+	before := c.Stats()
+
+	dec := json.NewDecoder(r)
+	var zero V
+	for line := 1; ; line++ {
+		var rec accessRecord[K]
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return Stats{}, fmt.Errorf("lru: reading access log line %d: %w", line, err)
+		}
+
+		switch rec.Op {
+		case opGet:
+			c.Get(rec.Key)
+		case opSet:
+			c.Set(rec.Key, zero)
+		case opDelete:
+			c.Delete(rec.Key)
+		default:
+			return Stats{}, fmt.Errorf("lru: unknown operation %q on access log line %d", rec.Op, line)
+		}
+	}
+
+	after := c.Stats()
+	return Stats{
+		Hits:       after.Hits - before.Hits,
+		Misses:     after.Misses - before.Misses,
+		Evictions:  after.Evictions - before.Evictions,
+		Insertions: after.Insertions - before.Insertions,
+		GhostHits:  after.GhostHits - before.GhostHits,
+	}, nil
+}
+
+func (s *SyncCache[K, V]) StartRecording(w io.Writer) {
+	s.cache.StartRecording(w)
+}
+
+func (s *SyncCache[K, V]) StopRecording() error {
+	return s.cache.StopRecording()
+}
+
+// StartRecording behaves like Cache.StartRecording, with the operations on
+// all shards written to w. Operations on different shards may be logged
+// slightly out of order.
+func (c *ShardedCache[K, V]) StartRecording(w io.Writer) {
+	r := &recorder{enc: json.NewEncoder(w)}
+	for _, s := range c.shards {
+		s.cache.mu.Lock()
+		s.cache.recorder = r
+		s.cache.mu.Unlock()
+	}
+}
+
+func (c *ShardedCache[K, V]) StopRecording() error {
+	var err error
+	for _, s := range c.shards {
+		if shardErr := s.StopRecording(); err == nil {
+			err = shardErr
+		}
+	}
+	return err
+}
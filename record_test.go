@@ -0,0 +1,85 @@
+package lru
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	var log bytes.Buffer
+	cache := New[string, int](3)
+	newTestClock(cache)
+
+	cache.StartRecording(&log)
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Get("Dog")
+	cache.Set("Soda", 3)
+	cache.Get("Cat")
+	cache.Delete("Soda")
+	cache.Get("Soda")
+	if err := cache.StopRecording(); err != nil {
+		t.Fatal(err)
+	}
+	cache.Get("Dog")
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 records, but got: %q", lines)
+	}
+	expected := `{"op":"get","key":"Dog","ts":` + "1704067200000000000}"
+	if lines[2] != expected {
+		t.Errorf("Expected record %s, but got: %s", expected, lines[2])
+	}
+
+	// With room for a single entry, Dog and Cat are evicted before they
+	// are read again.
+	stats, err := Replay(bytes.NewReader(log.Bytes()), New[string, int](1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Hits != 0 || stats.Misses != 3 || stats.Evictions != 2 || stats.Insertions != 3 {
+		t.Errorf("Unexpected stats for capacity 1: %+v", stats)
+	}
+
+	stats, err = Replay(bytes.NewReader(log.Bytes()), New[string, int](3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Evictions != 0 {
+		t.Errorf("Unexpected stats for capacity 3: %+v", stats)
+	}
+}
+
+func TestReplayInvalidLog(t *testing.T) {
+	_, err := Replay(strings.NewReader(`{"op":"get","key":"Dog","ts":1}`+"\n"+`{"op":"put","key":"Dog","ts":2}`), New[string, int](1))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error for the unknown operation on line 2, but got: %v", err)
+	}
+
+	_, err = Replay(strings.NewReader(`{"op":"get","key":1}`), New[string, int](1))
+	if err == nil {
+		t.Errorf("Expected an error for a key of the wrong type")
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+var errDiskFull = errors.New("disk full")
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errDiskFull
+}
+
+func TestRecordingWriteError(t *testing.T) {
+	cache, _ := NewSharded[string, int](4, 2)
+
+	cache.StartRecording(failingWriter{})
+	cache.Set("Dog", 1)
+	if err := cache.StopRecording(); !errors.Is(err, errDiskFull) {
+		t.Errorf("Expected the write error, but got: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package lru
+
+import "strings"
+
+// ScanPrefix returns every live entry of c whose key starts with prefix, in
+// unspecified order, without updating their positions. It walks the whole
+// hash, so it is meant for administrative tasks and debugging rather than
+// the hot path.
+func ScanPrefix[K ~string, V any](c *Cache[K, V], prefix string) []Entry[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var entries []Entry[K, V]
+	now := c.now()
+	for key, node := range c.Hash {
+		if strings.HasPrefix(string(key), prefix) && node.live(now) {
+			entries = append(entries, Entry[K, V]{Key: key, Value: node.Value})
+		}
+	}
+	return entries
+}
+
+// ScanPrefixSync is ScanPrefix for a SyncCache.
+func ScanPrefixSync[K ~string, V any](s *SyncCache[K, V], prefix string) []Entry[K, V] {
+	return ScanPrefix(s.cache, prefix)
+}
@@ -0,0 +1,42 @@
+package lru
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestScanPrefix(t *testing.T) {
+	cache := New[string, int](5)
+	clock := newTestClock(cache)
+
+	cache.Set("user:1", 1)
+	cache.Set("user:2", 2)
+	cache.SetWithTTL("user:3", 3, time.Second)
+	cache.Set("product:1", 4)
+	cache.Set("users", 5)
+	clock.Advance(2 * time.Second)
+
+	entries := ScanPrefix(cache, "user:")
+	var keys []string
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+	sort.Strings(keys)
+
+	expectedKeys := []string{"user:1", "user:2"}
+	if !equalSlice(expectedKeys, keys) {
+		t.Errorf("Expected keys: %v, but got: %v", expectedKeys, keys)
+	}
+
+	// Scanning must not change the recently used order.
+	expectedCacheState := []string{"users", "product:1", "user:3", "user:2", "user:1"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	if entries := ScanPrefixSync(NewSync[string, int](2), ""); len(entries) != 0 {
+		t.Errorf("Expected no entries in an empty cache, but got: %v", entries)
+	}
+}
@@ -0,0 +1,113 @@
+package lru
+
+import "container/list"
+
+// segment is an ordered group of cached nodes used by policies which split
+// the cache into several parts. The front holds the node pushed or moved
+// last.
+type segment[K comparable, V any] struct {
+	nodes *list.List
+	elems map[*Node[K, V]]*list.Element
+}
+
+func newSegment[K comparable, V any]() *segment[K, V] {
+	return &segment[K, V]{nodes: list.New(), elems: map[*Node[K, V]]*list.Element{}}
+}
+
+func (s *segment[K, V]) len() int {
+	return s.nodes.Len()
+}
+
+func (s *segment[K, V]) contains(node *Node[K, V]) bool {
+	_, ok := s.elems[node]
+	return ok
+}
+
+func (s *segment[K, V]) pushFront(node *Node[K, V]) {
+	s.elems[node] = s.nodes.PushFront(node)
+}
+
+func (s *segment[K, V]) moveToFront(node *Node[K, V]) {
+	s.nodes.MoveToFront(s.elems[node])
+}
+
+// remove takes node out of the segment and reports whether it was in it.
+func (s *segment[K, V]) remove(node *Node[K, V]) bool {
+	elem, ok := s.elems[node]
+	if !ok {
+		return false
+	}
+
+	s.nodes.Remove(elem)
+	delete(s.elems, node)
+	return true
+}
+
+// back returns the node pushed or moved longest ago, or nil if the segment is
+// empty.
+func (s *segment[K, V]) back() *Node[K, V] {
+	if elem := s.nodes.Back(); elem != nil {
+		return elem.Value.(*Node[K, V])
+	}
+	return nil
+}
+
+func (s *segment[K, V]) clear() {
+	s.nodes.Init()
+	s.elems = map[*Node[K, V]]*list.Element{}
+}
+
+// ghostList remembers the keys of recently evicted entries, without their
+// values, so a policy can notice when they come back.
+type ghostList[K comparable] struct {
+	keys  *list.List
+	elems map[K]*list.Element
+}
+
+func newGhostList[K comparable]() *ghostList[K] {
+	return &ghostList[K]{keys: list.New(), elems: map[K]*list.Element{}}
+}
+
+func (g *ghostList[K]) len() int {
+	return g.keys.Len()
+}
+
+func (g *ghostList[K]) contains(key K) bool {
+	_, ok := g.elems[key]
+	return ok
+}
+
+// push remembers key, forgetting the oldest keys once there are more than
+// limit of them.
+func (g *ghostList[K]) push(key K, limit int) {
+	g.remove(key)
+	g.elems[key] = g.keys.PushFront(key)
+	for g.keys.Len() > limit {
+		g.removeBack()
+	}
+}
+
+// remove forgets key and reports whether it was remembered.
+func (g *ghostList[K]) remove(key K) bool {
+	elem, ok := g.elems[key]
+	if !ok {
+		return false
+	}
+
+	g.keys.Remove(elem)
+	delete(g.elems, key)
+	return true
+}
+
+// removeBack forgets the oldest key.
+func (g *ghostList[K]) removeBack() {
+	if elem := g.keys.Back(); elem != nil {
+		g.keys.Remove(elem)
+		delete(g.elems, elem.Value.(K))
+	}
+}
+
+func (g *ghostList[K]) clear() {
+	g.keys.Init()
+	g.elems = map[K]*list.Element{}
+}
@@ -0,0 +1,313 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultShards is the number of shards used by NewSharded when none is given.
+const DefaultShards = 16
+
+// ErrInvalidShardCount is returned when the number of shards is not a power of
+// two.
+var ErrInvalidShardCount = errors.New("lru: shard count must be a power of two")
+
+// ShardedCache spreads entries over several independently locked SyncCache
+// shards to reduce lock contention. Recency is tracked per shard, so eviction
+// picks the least recently used entry of the shard a key hashes to.
+type ShardedCache[K comparable, V any] struct {
+	shards []*SyncCache[K, V]
+	mask   uint64
+
+	changesOnce sync.Once
+	changes     chan ChangeEvent[K, V]
+}
+
+var _ Interface[string, string] = (*ShardedCache[string, string])(nil)
+
+// NewSharded creates an empty sharded cache which holds at most capacity
+// entries, split as evenly as possible over the given number of shards. Every
+// shard holds at least one entry, so a capacity below the number of shards is
+// raised to it. A shard count of 0
+// selects DefaultShards. The options are applied to every shard, except that
+// a byte limit set with WithMaxBytes, a write rate limit set with
+// WithWriteRateLimit and the expected items of WithBloomFilter are split over
+// the shards too. A Policy set with WithPolicy cannot be shared by the shards,
+// which use the EvictionPolicy instead.
+func NewSharded[K comparable, V any](capacity, shards int, opts ...Option[K, V]) (*ShardedCache[K, V], error) {
+	if shards == 0 {
+		shards = DefaultShards
+	}
+	if shards < 0 || shards&(shards-1) != 0 {
+		return nil, ErrInvalidShardCount
+	}
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	c := &ShardedCache[K, V]{
+		shards: make([]*SyncCache[K, V], shards),
+		mask:   uint64(shards - 1),
+	}
+	for i := range c.shards {
+		// The first capacity%shards shards take one of the remaining entries
+		// each, and every shard needs room for at least one entry.
+		shardCapacity := capacity / shards
+		if i < capacity%shards {
+			shardCapacity++
+		}
+		c.shards[i] = NewSync(max(shardCapacity, 1), opts...)
+		if maxBytes := c.shards[i].cache.stats.maxBytes; maxBytes > 0 {
+			c.shards[i].cache.stats.maxBytes = (maxBytes + int64(shards) - 1) / int64(shards)
+		}
+		if limiter := c.shards[i].cache.limiter; limiter != nil {
+			c.shards[i].cache.limiter = rate.NewLimiter(limiter.Limit()/rate.Limit(shards), (limiter.Burst()+shards-1)/shards)
+		}
+		if bloom := c.shards[i].cache.bloom; bloom != nil {
+			c.shards[i].cache.bloom = newBloomFilter((bloom.expectedItems+shards-1)/shards, bloom.falsePositiveRate)
+		}
+		if c.shards[i].cache.customPolicy != nil {
+			c.shards[i].cache.customPolicy = nil
+			c.shards[i].cache.policy = newPolicy(c.shards[i].cache.evictionPolicy, c.shards[i].cache)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *ShardedCache[K, V]) shard(key K) *SyncCache[K, V] {
+	return c.shards[hashKey(key)&c.mask]
+}
+
+func (c *ShardedCache[K, V]) Get(key K) (V, bool) {
+	return c.shard(key).Get(key)
+}
+
+func (c *ShardedCache[K, V]) Fetch(key K) (V, error) {
+	return c.shard(key).Fetch(key)
+}
+
+func (c *ShardedCache[K, V]) GetContext(ctx context.Context, key K) (V, bool, error) {
+	return c.shard(key).GetContext(ctx, key)
+}
+
+func (c *ShardedCache[K, V]) SetContext(ctx context.Context, key K, value V) error {
+	return c.shard(key).SetContext(ctx, key, value)
+}
+
+func (c *ShardedCache[K, V]) Set(key K, value V) error {
+	return c.shard(key).Set(key, value)
+}
+
+func (c *ShardedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) error {
+	return c.shard(key).SetWithTTL(key, value, ttl)
+}
+
+func (c *ShardedCache[K, V]) SetWithSWR(key K, value V, ttl, stale time.Duration) error {
+	return c.shard(key).SetWithSWR(key, value, ttl, stale)
+}
+
+// SetWithCost behaves like Cache.SetWithCost. The capacity is split evenly
+// over the shards, so an entry may cost at most the capacity of a single
+// shard.
+func (c *ShardedCache[K, V]) SetWithCost(key K, value V, cost int) error {
+	return c.shard(key).SetWithCost(key, value, cost)
+}
+
+func (c *ShardedCache[K, V]) SetWithTags(key K, value V, tags ...string) error {
+	return c.shard(key).SetWithTags(key, value, tags...)
+}
+
+func (c *ShardedCache[K, V]) Tags(key K) []string {
+	return c.shard(key).Tags(key)
+}
+
+// InvalidateByTag removes every entry tagged with tag from all shards and
+// returns how many were removed.
+func (c *ShardedCache[K, V]) InvalidateByTag(tag string) int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.InvalidateByTag(tag)
+	}
+	return n
+}
+
+func (c *ShardedCache[K, V]) GetOrSet(key K, loader func() (V, error)) (V, error) {
+	return c.shard(key).GetOrSet(key, loader)
+}
+
+func (c *ShardedCache[K, V]) SetNX(key K, value V) bool {
+	return c.shard(key).SetNX(key, value)
+}
+
+func (c *ShardedCache[K, V]) Swap(key K, value V) (V, bool) {
+	return c.shard(key).Swap(key, value)
+}
+
+func (c *ShardedCache[K, V]) Delete(key K) bool {
+	return c.shard(key).Delete(key)
+}
+
+func (c *ShardedCache[K, V]) Peek(key K) (V, bool) {
+	return c.shard(key).Peek(key)
+}
+
+func (c *ShardedCache[K, V]) Contains(key K) bool {
+	return c.shard(key).Contains(key)
+}
+
+func (c *ShardedCache[K, V]) TTL(key K) (time.Duration, bool) {
+	return c.shard(key).TTL(key)
+}
+
+// Clear removes all entries from every shard.
+func (c *ShardedCache[K, V]) Clear() {
+	for _, s := range c.shards {
+		s.Clear()
+	}
+}
+
+// DeleteExpired removes every expired entry from all shards and returns how
+// many were removed.
+func (c *ShardedCache[K, V]) DeleteExpired() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.DeleteExpired()
+	}
+	return n
+}
+
+// StartJanitor starts a goroutine which removes expired entries from all
+// shards every interval. Call the returned function to stop it.
+func (c *ShardedCache[K, V]) StartJanitor(interval time.Duration) (stop func()) {
+	return startJanitor(interval, c.DeleteExpired)
+}
+
+// ForEach calls fn for every entry, shard by shard. Entries are ordered from
+// the most to the least recently used one within a shard only.
+func (c *ShardedCache[K, V]) ForEach(fn func(key K, value V) bool) {
+	stopped := false
+	for _, s := range c.shards {
+		s.ForEach(func(key K, value V) bool {
+			stopped = !fn(key, value)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// Snapshot copies the live entries of all shards into a Snapshot. Each shard
+// is copied at a slightly different time, and the entries are ordered from
+// the most to the least recently used one within a shard only.
+func (c *ShardedCache[K, V]) Snapshot() *Snapshot[K, V] {
+	var entries []Entry[K, V]
+	for _, s := range c.shards {
+		entries = append(entries, s.Snapshot().entries...)
+	}
+	return newSnapshot(entries)
+}
+
+// Stats returns the usage counters summed over all shards.
+func (c *ShardedCache[K, V]) Stats() Stats {
+	var total Stats
+	for _, s := range c.shards {
+		st := s.Stats()
+		total.Hits += st.Hits
+		total.Misses += st.Misses
+		total.Evictions += st.Evictions
+		total.Insertions += st.Insertions
+		total.GhostHits += st.GhostHits
+		total.CurrentBytes += st.CurrentBytes
+		total.MaxBytes += st.MaxBytes
+	}
+	return total
+}
+
+// ResetStats sets the usage counters of every shard back to zero.
+func (c *ShardedCache[K, V]) ResetStats() {
+	for _, s := range c.shards {
+		s.ResetStats()
+	}
+}
+
+// Len returns the number of entries held by all shards.
+func (c *ShardedCache[K, V]) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+// Cost returns the total cost of the entries held by all shards.
+func (c *ShardedCache[K, V]) Cost() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Cost()
+	}
+	return n
+}
+
+// Cap returns the combined capacity of all shards.
+func (c *ShardedCache[K, V]) Cap() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Cap()
+	}
+	return n
+}
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// hashKey computes the 64-bit FNV-1a hash of key. Strings and integers are
+// hashed directly; other key types are hashed through their Go syntax
+// representation.
+func hashKey[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return fnv1aString(k)
+	case int:
+		return fnv1aUint64(uint64(k))
+	case int64:
+		return fnv1aUint64(uint64(k))
+	case int32:
+		return fnv1aUint64(uint64(k))
+	case uint:
+		return fnv1aUint64(uint64(k))
+	case uint64:
+		return fnv1aUint64(k)
+	case uint32:
+		return fnv1aUint64(uint64(k))
+	default:
+		return fnv1aString(fmt.Sprintf("%#v", key))
+	}
+}
+
+func fnv1aString(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+func fnv1aUint64(v uint64) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < 8; i++ {
+		h ^= v & 0xff
+		h *= fnvPrime64
+		v >>= 8
+	}
+	return h
+}
@@ -0,0 +1,66 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Sample results on a 1 CPU sandbox (go test -bench Contention -benchtime 200000x):
+//
+//	BenchmarkContention/single/goroutines=8     53.24 ns/op   0 B/op   0 allocs/op
+//	BenchmarkContention/sharded/goroutines=8    65.46 ns/op   0 B/op   0 allocs/op
+//	BenchmarkContention/single/goroutines=16    52.94 ns/op   0 B/op   0 allocs/op
+//	BenchmarkContention/sharded/goroutines=16   67.05 ns/op   0 B/op   0 allocs/op
+//	BenchmarkContention/single/goroutines=32    53.99 ns/op   0 B/op   0 allocs/op
+//	BenchmarkContention/sharded/goroutines=32   65.45 ns/op   0 B/op   0 allocs/op
+//
+// With a single CPU there is no lock contention to remove, so sharding only
+// adds the cost of hashing the key. Contention, and therefore any gain from
+// sharding, only shows up when the goroutines run on several CPUs.
+
+const benchCapacity = 1024
+
+func BenchmarkContention(b *testing.B) {
+	keys := make([]string, benchCapacity*2)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("Element%d", i)
+	}
+
+	for _, goroutines := range []int{8, 16, 32} {
+		b.Run(fmt.Sprintf("single/goroutines=%d", goroutines), func(b *testing.B) {
+			benchmarkConcurrent(b, NewSync[string, int](benchCapacity), keys, goroutines)
+		})
+		b.Run(fmt.Sprintf("sharded/goroutines=%d", goroutines), func(b *testing.B) {
+			cache, err := NewSharded[string, int](benchCapacity, DefaultShards)
+			if err != nil {
+				b.Fatal(err)
+			}
+			benchmarkConcurrent(b, cache, keys, goroutines)
+		})
+	}
+}
+
+// benchmarkConcurrent splits b.N mixed Get/Set operations evenly between the
+// given number of goroutines.
+func benchmarkConcurrent(b *testing.B, cache Interface[string, int], keys []string, goroutines int) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < b.N; i += goroutines {
+				key := keys[i%len(keys)]
+				if i%4 == 0 {
+					cache.Set(key, i)
+				} else {
+					cache.Get(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
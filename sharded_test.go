@@ -0,0 +1,103 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"testing"
+)
+
+func TestShardedCache(t *testing.T) {
+	cache, err := NewSharded[string, int](64, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cache.Cap() != 64 {
+		t.Errorf("Expected Cap 64, but got: %d", cache.Cap())
+	}
+
+	for i := 0; i < 32; i++ {
+		cache.Set(fmt.Sprintf("Element%d", i), i)
+	}
+	for i := 0; i < 32; i++ {
+		key := fmt.Sprintf("Element%d", i)
+		if value, found := cache.Get(key); !found || value != i {
+			t.Errorf("Expected (%d, true) for %s, but got: (%d, %t)", i, key, value, found)
+		}
+	}
+	if cache.Len() != 32 {
+		t.Errorf("Expected Len 32, but got: %d", cache.Len())
+	}
+
+	if !cache.Delete("Element0") || cache.Contains("Element0") {
+		t.Errorf("Expected Element0 to be deleted")
+	}
+
+	visited := 0
+	cache.ForEach(func(key string, value int) bool {
+		visited++
+		return visited < 5
+	})
+	if visited != 5 {
+		t.Errorf("Expected ForEach to stop after 5 entries, but visited: %d", visited)
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("Expected empty cache after Clear, but got Len: %d", cache.Len())
+	}
+}
+
+func TestShardedCacheShardCount(t *testing.T) {
+	cache, err := NewSharded[string, int](64, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cache.shards) != DefaultShards {
+		t.Errorf("Expected %d shards, but got: %d", DefaultShards, len(cache.shards))
+	}
+
+	for _, shards := range []int{-2, 3, 12} {
+		if _, err := NewSharded[string, int](64, shards); !errors.Is(err, ErrInvalidShardCount) {
+			t.Errorf("Expected ErrInvalidShardCount for %d shards, but got: %v", shards, err)
+		}
+	}
+}
+
+func TestShardedCacheCapacity(t *testing.T) {
+	tests := []struct {
+		capacity, shards int
+		want             []int
+	}{
+		{64, 4, []int{16, 16, 16, 16}},
+		{10, 4, []int{3, 3, 2, 2}},
+		{10, 16, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		cache, err := NewSharded[string, int](tt.capacity, tt.shards)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		total := 0
+		for i, s := range cache.shards {
+			if s.Cap() != tt.want[i] {
+				t.Errorf("Expected shard %d of NewSharded(%d, %d) to hold %d entries, but got: %d", i, tt.capacity, tt.shards, tt.want[i], s.Cap())
+			}
+			total += tt.want[i]
+		}
+		if cache.Cap() != total {
+			t.Errorf("Expected NewSharded(%d, %d).Cap() to be %d, but got: %d", tt.capacity, tt.shards, total, cache.Cap())
+		}
+	}
+}
+
+func TestHashKeyMatchesFNV1a(t *testing.T) {
+	for _, key := range []string{"", "Dog", "Element42"} {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		if hashKey(key) != h.Sum64() {
+			t.Errorf("Expected hashKey(%q) to equal FNV-1a %d, but got: %d", key, h.Sum64(), hashKey(key))
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Simulator models a cache of a given capacity evicting by a Policy, without
+// storing values. It only tracks which keys would be cached, and counts hits,
+// misses and evictions, so policies can be evaluated against real traces
+// cheaply. A Simulator is not safe for concurrent use.
+type Simulator[K comparable] struct {
+	capacity int
+	policy   Policy[K]
+	keys     map[K]struct{}
+
+	hits      int
+	misses    int
+	evictions int
+}
+
+// SimResult describes how a policy fared in a simulation.
+type SimResult struct {
+	// PolicyName is the String of the policy when it has one, such as "LRU"
+	// for NewLRUPolicy, and its type otherwise.
+	PolicyName string
+	HitRate    float64
+	Hits       int
+	Misses     int
+	Evictions  int
+}
+
+// NewSimulator creates a Simulator for a cache holding at most capacity keys,
+// which is raised to 1 if it is not positive. policy is reset first.
+func NewSimulator[K comparable](capacity int, policy Policy[K]) *Simulator[K] {
+	policy.Reset()
+	return &Simulator[K]{
+		capacity: max(capacity, 1),
+		policy:   policy,
+		keys:     map[K]struct{}{},
+	}
+}
+
+// Get reports whether key would be cached, counting a hit or a miss.
+func (s *Simulator[K]) Get(key K) bool {
+	if _, ok := s.keys[key]; !ok {
+		s.misses++
+		return false
+	}
+
+	s.hits++
+	s.policy.OnAccess(key)
+	return true
+}
+
+// Set adds key, evicting the key chosen by the policy if the simulated cache
+// is full.
+func (s *Simulator[K]) Set(key K) {
+	if _, ok := s.keys[key]; ok {
+		s.policy.OnAccess(key)
+		return
+	}
+
+	if len(s.keys) >= s.capacity {
+		victim := s.policy.Evict()
+		delete(s.keys, victim)
+		s.policy.OnDelete(victim)
+		s.evictions++
+	}
+
+	s.keys[key] = struct{}{}
+	s.policy.OnInsert(key)
+}
+
+// Result returns the counts of the simulation so far.
+func (s *Simulator[K]) Result() SimResult {
+	result := SimResult{
+		PolicyName: policyName(s.policy),
+		Hits:       s.hits,
+		Misses:     s.misses,
+		Evictions:  s.evictions,
+	}
+	if total := s.hits + s.misses; total > 0 {
+		result.HitRate = float64(s.hits) / float64(total)
+	}
+	return result
+}
+
+// SimulateTrace runs trace through a Simulator of the given capacity for each
+// of policies, concurrently, and returns their results in the same order.
+// Every key of trace is a read which fills the cache on a miss. The policies
+// are reset first and must not be shared between calls running at the same
+// time.
+func SimulateTrace[K comparable](trace []K, capacity int, policies ...Policy[K]) []SimResult {
+	results := make([]SimResult, len(policies))
+
+	var wg sync.WaitGroup
+	for i, policy := range policies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sim := NewSimulator(capacity, policy)
+			for _, key := range trace {
+				if !sim.Get(key) {
+					sim.Set(key)
+				}
+			}
+			results[i] = sim.Result()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func policyName(p any) string {
+	if s, ok := p.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", p)
+}
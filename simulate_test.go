@@ -0,0 +1,50 @@
+package lru
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSimulator(t *testing.T) {
+	sim := NewSimulator(2, NewLRUPolicy[string]())
+
+	sim.Set("Dog")
+	sim.Set("Cat")
+	sim.Get("Dog")
+	sim.Set("Soda")
+
+	if sim.Get("Cat") || !sim.Get("Dog") || !sim.Get("Soda") {
+		t.Error("Expected the least recently used Cat to be evicted")
+	}
+	expected := SimResult{PolicyName: "LRU", HitRate: 0.75, Hits: 3, Misses: 1, Evictions: 1}
+	if result := sim.Result(); result != expected {
+		t.Errorf("Expected %+v, but got: %+v", expected, result)
+	}
+}
+
+// TestSimulateTrace checks the simulation against the same trace replayed
+// by ComparePolicies on real caches.
+func TestSimulateTrace(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rng, 1.2, 1, 200)
+
+	var trace []uint64
+	var ops []Op[uint64]
+	for i := 0; i < 10000; i++ {
+		key := zipf.Uint64()
+		trace = append(trace, key)
+		ops = append(ops, Op[uint64]{Key: key, Type: OpGet}, Op[uint64]{Key: key, Type: OpSet})
+	}
+
+	results := SimulateTrace(trace, 20,
+		NewLRUPolicy[uint64](), NewLFUPolicy[uint64](), NewFIFOPolicy[uint64](), NewClockPolicy[uint64]())
+	reports := ComparePolicies(ops, []EvictionPolicy{PolicyLRU, PolicyLFU, PolicyFIFO, PolicyClock}, 20)
+
+	for i, result := range results {
+		report := reports[i]
+		if result.PolicyName != report.Policy || result.HitRate != report.HitRate || uint64(result.Evictions) != report.Stats.Evictions {
+			t.Errorf("Expected %s with hit rate %.3f and %d evictions, but got: %+v",
+				report.Policy, report.HitRate, report.Stats.Evictions, result)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package sketch
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrPrecisionMismatch is returned by HyperLogLog.Merge for sketches created
+// with different precisions.
+var ErrPrecisionMismatch = errors.New("sketch: HyperLogLog precisions differ")
+
+// Precisions accepted by NewHyperLogLog. Out of range values are clamped.
+const (
+	MinPrecision = 4
+	MaxPrecision = 16
+)
+
+// HyperLogLog estimates the number of distinct keys in a stream with 2^p
+// one-byte registers, for a precision p. The standard error of the estimate
+// is about 1.04/sqrt(2^p): 1.6% for a precision of 12, which takes 4 KiB.
+// Sketches of the same precision can be merged to estimate the distinct keys
+// of their combined streams.
+//
+// A HyperLogLog is not safe for concurrent use.
+type HyperLogLog struct {
+	registers []uint8
+	precision uint8
+}
+
+// NewHyperLogLog creates an empty sketch with 2^precision registers.
+func NewHyperLogLog(precision int) *HyperLogLog {
+	precision = min(max(precision, MinPrecision), MaxPrecision)
+	return &HyperLogLog{
+		registers: make([]uint8, 1<<precision),
+		precision: uint8(precision),
+	}
+}
+
+// Add records key.
+func (h *HyperLogLog) Add(key string) {
+	h.AddHash(hashString(key))
+}
+
+// AddHash is Add for a key which was already hashed to 64 bits, so keys of
+// any type can be counted. The hash is mixed again first, so hashes which
+// differ in few bits, like FNV-1a hashes of small integers, are fine.
+func (h *HyperLogLog) AddHash(hash uint64) {
+	hash = mix(hash)
+
+	// The top bits select a register, which keeps the longest run of
+	// leading zeros seen in the remaining bits, plus one.
+	idx := hash >> (64 - h.precision)
+	rest := hash<<h.precision | 1<<(h.precision-1)
+	if rank := uint8(bits.LeadingZeros64(rest) + 1); rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Count returns the estimated number of distinct keys added.
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	switch m {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	}
+	estimate := alpha * m * m / sum
+
+	// Small cardinalities are estimated better by linear counting of the
+	// empty registers. With 64-bit hashes no large range correction is
+	// needed.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// Merge adds the keys of other to h, as if they had been added to h directly.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return ErrPrecisionMismatch
+	}
+	for i, r := range other.registers {
+		h.registers[i] = max(h.registers[i], r)
+	}
+	return nil
+}
+
+// Reset forgets every key.
+func (h *HyperLogLog) Reset() {
+	clear(h.registers)
+}
+
+// mix is the splitmix64 finalizer, spreading every input bit over the whole
+// hash.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
@@ -0,0 +1,113 @@
+// Package sketch provides a Count-Min Sketch for estimating how often keys
+// occur in a stream, as used by the W-TinyLFU eviction policy of the lru
+// package, and a HyperLogLog for estimating how many distinct keys it holds,
+// as used by its WorkingSetEstimator. Both are also useful on their own, for
+// example to detect hot keys.
+package sketch
+
+// CountMinSketch estimates how often keys were seen with four 4-bit counters
+// per key, packed sixteen to a word. The counters of a key are picked by
+// double hashing, and its estimate is the smallest of them, so estimates can
+// be too high but never too low, up to the maximum of 15. Once as many keys
+// were counted as ten times the number of counters, every counter is halved,
+// so the estimates follow recent frequency.
+//
+// A CountMinSketch is not safe for concurrent use.
+type CountMinSketch struct {
+	table      []uint64
+	mask       uint64
+	additions  int
+	sampleSize int
+}
+
+// MaxCount is the largest estimate a CountMinSketch returns.
+const MaxCount = 15
+
+// New creates an empty sketch sized to track about capacity distinct keys.
+func New(capacity int) *CountMinSketch {
+	// The table holds at least four counters per key, rounded up to a power
+	// of two so indexes can be masked.
+	counters := 16
+	for counters < 4*capacity {
+		counters <<= 1
+	}
+
+	return &CountMinSketch{
+		table:      make([]uint64, counters/16),
+		mask:       uint64(counters - 1),
+		sampleSize: 10 * counters,
+	}
+}
+
+// Add counts one occurrence of key.
+func (s *CountMinSketch) Add(key string) {
+	s.AddHash(hashString(key))
+}
+
+// Count returns the estimated number of occurrences of key since it was last
+// halved, at most MaxCount.
+func (s *CountMinSketch) Count(key string) uint64 {
+	return s.CountHash(hashString(key))
+}
+
+// AddHash is Add for a key which was already hashed to 64 bits, so keys of
+// any type can be counted.
+func (s *CountMinSketch) AddHash(hash uint64) {
+	for i := 0; i < 4; i++ {
+		idx := s.index(hash, i)
+		if s.counter(idx) < MaxCount {
+			s.table[idx/16] += 1 << (idx % 16 * 4)
+		}
+	}
+
+	s.additions++
+	if s.additions >= s.sampleSize {
+		s.halve()
+	}
+}
+
+// CountHash is Count for a key which was already hashed with the same
+// function as passed to AddHash.
+func (s *CountMinSketch) CountHash(hash uint64) uint64 {
+	count := uint64(MaxCount)
+	for i := 0; i < 4; i++ {
+		count = min(count, s.counter(s.index(hash, i)))
+	}
+	return count
+}
+
+// Reset sets every counter back to zero.
+func (s *CountMinSketch) Reset() {
+	clear(s.table)
+	s.additions = 0
+}
+
+// index returns the i-th of the four counters for hash. The two hashes
+// combined by double hashing are hash itself and a multiplicative hash of it,
+// made odd so the four counters differ.
+func (s *CountMinSketch) index(hash uint64, i int) uint64 {
+	step := (hash*0x9e3779b97f4a7c15)>>32 | 1
+	return (hash + uint64(i)*step) & s.mask
+}
+
+func (s *CountMinSketch) counter(idx uint64) uint64 {
+	return s.table[idx/16] >> (idx % 16 * 4) & 0xf
+}
+
+func (s *CountMinSketch) halve() {
+	for i, word := range s.table {
+		s.table[i] = word >> 1 & 0x7777777777777777
+	}
+	s.additions /= 2
+}
+
+// hashString computes the 64-bit FNV-1a hash of key, matching how the lru
+// package hashes string keys.
+func hashString(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return h
+}
@@ -0,0 +1,133 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	sketch := New(64)
+
+	for i := 0; i < 10; i++ {
+		sketch.Add("Dog")
+	}
+	sketch.Add("Cat")
+
+	if count := sketch.Count("Dog"); count < 10 {
+		t.Errorf("Expected Dog count of at least 10, but got: %d", count)
+	}
+	if count := sketch.Count("Cat"); count < 1 || count >= 10 {
+		t.Errorf("Expected Cat count between 1 and 10, but got: %d", count)
+	}
+
+	// Counters saturate at MaxCount.
+	for i := 0; i < 20; i++ {
+		sketch.Add("Dog")
+	}
+	if count := sketch.Count("Dog"); count != MaxCount {
+		t.Errorf("Expected Dog count to saturate at %d, but got: %d", MaxCount, count)
+	}
+
+	sketch.halve()
+	if count := sketch.Count("Dog"); count != 7 {
+		t.Errorf("Expected halving to bring Dog down to 7, but got: %d", count)
+	}
+
+	sketch.Reset()
+	if count := sketch.Count("Dog"); count != 0 {
+		t.Errorf("Expected Reset to clear Dog, but got: %d", count)
+	}
+}
+
+func TestCountMinSketchAging(t *testing.T) {
+	sketch := New(1024)
+
+	for i := 0; i < 8; i++ {
+		sketch.Add("Dog")
+	}
+
+	// Count other keys until the sample is full and the counters are halved.
+	for i, before := 0, 0; sketch.additions >= before; i++ {
+		before = sketch.additions
+		sketch.Add(fmt.Sprintf("Element%d", i))
+	}
+
+	if count := sketch.Count("Dog"); count >= 8 {
+		t.Errorf("Expected Dog count to be halved once the sample is full, but got: %d", count)
+	}
+}
+
+func TestCountMinSketchHotKeys(t *testing.T) {
+	sketch := New(1000)
+
+	// A few hot keys among many keys seen once stand out.
+	for i := 0; i < 1000; i++ {
+		sketch.Add(fmt.Sprintf("Element%d", i))
+		if i%100 == 0 {
+			for j := 0; j < 5; j++ {
+				sketch.Add("Hot")
+			}
+		}
+	}
+
+	if count := sketch.Count("Hot"); count != MaxCount {
+		t.Errorf("Expected Hot to saturate, but got: %d", count)
+	}
+	overestimated := 0
+	for i := 0; i < 1000; i++ {
+		if sketch.Count(fmt.Sprintf("Element%d", i)) > 2 {
+			overestimated++
+		}
+	}
+	if overestimated > 10 {
+		t.Errorf("Expected few keys seen once to be estimated above 2, but got: %d", overestimated)
+	}
+}
+
+func TestHyperLogLog(t *testing.T) {
+	hll := NewHyperLogLog(12)
+	if count := hll.Count(); count != 0 {
+		t.Errorf("Expected an empty sketch to count 0, but got: %d", count)
+	}
+
+	for _, n := range []int{10, 1000, 100000} {
+		hll.Reset()
+		for i := 0; i < n; i++ {
+			// Duplicates must not be counted.
+			hll.Add(fmt.Sprint(i))
+			hll.Add(fmt.Sprint(i))
+		}
+		if count := float64(hll.Count()); count < 0.95*float64(n) || count > 1.05*float64(n) {
+			t.Errorf("Expected about %d distinct keys, but got: %.0f", n, count)
+		}
+	}
+
+	// Hashes of consecutive integers are spread too.
+	hll.Reset()
+	for i := uint64(0); i < 1000; i++ {
+		hll.AddHash(i)
+	}
+	if count := hll.Count(); count < 950 || count > 1050 {
+		t.Errorf("Expected about 1000 distinct hashes, but got: %d", count)
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a, b := NewHyperLogLog(12), NewHyperLogLog(12)
+	for i := 0; i < 1000; i++ {
+		a.Add(fmt.Sprint(i))
+		b.Add(fmt.Sprint(i + 500))
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count := a.Count(); count < 1425 || count > 1575 {
+		t.Errorf("Expected about 1500 distinct keys, but got: %d", count)
+	}
+
+	if err := a.Merge(NewHyperLogLog(10)); !errors.Is(err, ErrPrecisionMismatch) {
+		t.Errorf("Expected ErrPrecisionMismatch, but got: %v", err)
+	}
+}
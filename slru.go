@@ -0,0 +1,74 @@
+package lru
+
+// DefaultSLRUProtectedRatio is the share of the capacity PolicySLRU gives to
+// the protected segment when given an invalid ratio.
+const DefaultSLRUProtectedRatio = 0.8
+
+// PolicySLRU returns a segmented LRU policy. New entries start in a
+// probationary segment and graduate to a protected segment, which gets
+// protectedRatio of the capacity, when they are used again. An overflowing
+// protected segment demotes its least recently used entry back to probation
+// instead of evicting it, so only entries used once, or not used for a long
+// time, are evicted. Ratios outside of (0, 1) are replaced by
+// DefaultSLRUProtectedRatio.
+func PolicySLRU(protectedRatio float64) EvictionPolicy {
+	if protectedRatio <= 0 || protectedRatio >= 1 {
+		protectedRatio = DefaultSLRUProtectedRatio
+	}
+	return EvictionPolicy{kind: policySLRU, ratio: protectedRatio}
+}
+
+type slruPolicy[K comparable, V any] struct {
+	capacity       *int
+	protectedRatio float64
+
+	probation *segment[K, V]
+	protected *segment[K, V]
+}
+
+func newSLRUPolicy[K comparable, V any](capacity *int, protectedRatio float64) *slruPolicy[K, V] {
+	return &slruPolicy[K, V]{
+		capacity:       capacity,
+		protectedRatio: protectedRatio,
+		probation:      newSegment[K, V](),
+		protected:      newSegment[K, V](),
+	}
+}
+
+func (p *slruPolicy[K, V]) add(node *Node[K, V]) {
+	p.probation.pushFront(node)
+}
+
+func (p *slruPolicy[K, V]) access(node *Node[K, V]) bool {
+	if !p.probation.remove(node) {
+		p.protected.moveToFront(node)
+		return true
+	}
+
+	p.protected.pushFront(node)
+	limit := max(1, int(p.protectedRatio*float64(*p.capacity)))
+	for p.protected.len() > limit {
+		demoted := p.protected.back()
+		p.protected.remove(demoted)
+		p.probation.pushFront(demoted)
+	}
+	return true
+}
+
+func (p *slruPolicy[K, V]) remove(node *Node[K, V]) {
+	if !p.probation.remove(node) {
+		p.protected.remove(node)
+	}
+}
+
+func (p *slruPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	if node := p.probation.back(); node != nil {
+		return node
+	}
+	return p.protected.back()
+}
+
+func (p *slruPolicy[K, V]) clear() {
+	p.probation.clear()
+	p.protected.clear()
+}
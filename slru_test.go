@@ -0,0 +1,74 @@
+package lru
+
+import "testing"
+
+func TestPolicySLRU(t *testing.T) {
+	cache := New(4, WithEvictionPolicy[string, int](PolicySLRU(0.5)))
+	policy := cache.policy.(*slruPolicy[string, int])
+
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Soda", 3)
+	cache.Get("Dog")
+	cache.Get("Cat")
+
+	if policy.protected.len() != 2 || policy.probation.len() != 1 {
+		t.Fatalf("Expected 2 protected and 1 probationary entry, but got: %d and %d", policy.protected.len(), policy.probation.len())
+	}
+
+	// Protected holds two entries, so promoting Soda demotes Dog instead of
+	// evicting it.
+	cache.Get("Soda")
+	if !policy.probation.contains(cache.Hash["Dog"]) {
+		t.Errorf("Expected Dog to be demoted to probation")
+	}
+	if cache.Len() != 3 {
+		t.Errorf("Expected demotion not to evict, but got Len: %d", cache.Len())
+	}
+
+	// Evictions come from probation: first Dog, then the new entries.
+	cache.Set("Tee", 4)
+	cache.Set("Car", 5)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Dog to be evicted from probation")
+	}
+	cache.Set("Terry", 6)
+	if cache.Contains("Tee") || !cache.Contains("Cat") || !cache.Contains("Soda") {
+		t.Errorf("Expected probationary Tee to be evicted before protected entries")
+	}
+}
+
+func TestPolicySLRUScan(t *testing.T) {
+	cache := New(100, WithEvictionPolicy[int, int](PolicySLRU(DefaultSLRUProtectedRatio)))
+
+	for i := 0; i < 50; i++ {
+		cache.Set(-i-1, i)
+		cache.Get(-i - 1)
+	}
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+
+	for i := 0; i < 50; i++ {
+		if !cache.Contains(-i - 1) {
+			t.Fatalf("Expected protected entry %d to survive a scan", -i-1)
+		}
+	}
+}
+
+func TestPolicySLRUOnlyProtected(t *testing.T) {
+	cache := New(2, WithEvictionPolicy[string, int](PolicySLRU(2)))
+
+	cache.Set("Dog", 1)
+	cache.Get("Dog")
+	cache.Set("Cat", 2)
+	cache.Get("Cat")
+	cache.Delete("Cat")
+
+	// With probation empty the protected entry is evicted.
+	cache.Resize(1)
+	cache.Set("Soda", 3)
+	if cache.Contains("Dog") || !cache.Contains("Soda") {
+		t.Errorf("Expected Dog to be evicted once probation is empty")
+	}
+}
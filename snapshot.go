@@ -0,0 +1,64 @@
+package lru
+
+// Snapshot is a read-only copy of the entries of a cache taken at one point
+// in time. It does not change when the cache does, and since it keeps no
+// recently used order, reading from it is safe from several goroutines.
+type Snapshot[K comparable, V any] struct {
+	entries []Entry[K, V]
+	index   map[K]int
+}
+
+// Snapshot copies the live entries of the cache into a Snapshot, without
+// updating their positions.
+func (c *Cache[K, V]) Snapshot() *Snapshot[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entries := make([]Entry[K, V], 0, c.LinkedList.Length)
+	now := c.now()
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		if node.live(now) {
+			entries = append(entries, Entry[K, V]{Key: node.Key, Value: node.Value})
+		}
+	}
+	return newSnapshot(entries)
+}
+
+func newSnapshot[K comparable, V any](entries []Entry[K, V]) *Snapshot[K, V] {
+	index := make(map[K]int, len(entries))
+	for i, entry := range entries {
+		index[entry.Key] = i
+	}
+	return &Snapshot[K, V]{entries: entries, index: index}
+}
+
+// Get returns the value stored under key when the snapshot was taken.
+func (s *Snapshot[K, V]) Get(key K) (V, bool) {
+	i, ok := s.index[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return s.entries[i].Value, true
+}
+
+// Keys returns the keys in the snapshot, ordered from the most to the least
+// recently used one at the time it was taken.
+func (s *Snapshot[K, V]) Keys() []K {
+	keys := make([]K, len(s.entries))
+	for i, entry := range s.entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// Entries returns a copy of the entries in the snapshot, in the same order as
+// Keys.
+func (s *Snapshot[K, V]) Entries() []Entry[K, V] {
+	return append([]Entry[K, V](nil), s.entries...)
+}
+
+// Len returns the number of entries in the snapshot.
+func (s *Snapshot[K, V]) Len() int {
+	return len(s.entries)
+}
@@ -0,0 +1,62 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	cache.SetWithTTL("Cat", 2, time.Second)
+	cache.Set("Soda", 3)
+	cache.Get("Dog")
+
+	clock.Advance(2 * time.Second)
+	snapshot := cache.Snapshot()
+
+	// Taking the snapshot neither promotes entries nor includes expired ones.
+	expectedKeys := []string{"Dog", "Soda"}
+	if !equalSlice(expectedKeys, snapshot.Keys()) {
+		t.Errorf("Expected snapshot keys: %v, but got: %v", expectedKeys, snapshot.Keys())
+	}
+	expectedCacheState := []string{"Dog", "Soda", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	// Later writes do not show up in the snapshot.
+	cache.Set("Dog", 10)
+	cache.Delete("Soda")
+	cache.Set("Tee", 4)
+
+	if value, found := snapshot.Get("Dog"); !found || value != 1 {
+		t.Errorf("Expected (1, true), but got: (%d, %t)", value, found)
+	}
+	if value, found := snapshot.Get("Soda"); !found || value != 3 {
+		t.Errorf("Expected (3, true), but got: (%d, %t)", value, found)
+	}
+	if _, found := snapshot.Get("Tee"); found {
+		t.Errorf("Expected entries added later to be missing")
+	}
+	if snapshot.Len() != 2 || len(snapshot.Entries()) != 2 {
+		t.Errorf("Expected 2 entries, but got: %d", snapshot.Len())
+	}
+}
+
+func TestShardedSnapshot(t *testing.T) {
+	cache, err := NewSharded[string, int](16, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+
+	snapshot := cache.Snapshot()
+	if value, found := snapshot.Get("Cat"); !found || value != 2 || snapshot.Len() != 2 {
+		t.Errorf("Expected a snapshot of both shards, but got: %v", snapshot.Entries())
+	}
+}
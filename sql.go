@@ -0,0 +1,49 @@
+package lru
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// WithSQLLoader makes Get fill misses from db, see WithLoaderContext. query
+// is prepared on the first miss and run with the key as its only argument,
+// and scanFn reads the value from the resulting row. A query which returns no
+// rows fails with an error matching both ErrNotFound and sql.ErrNoRows, so
+// the miss can be cached with WithNegativeCaching(ttl, ErrNotFound). The
+// context passed to GetContext cancels the query.
+func WithSQLLoader[K comparable, V any](db *sql.DB, query string, scanFn func(*sql.Row) (V, error)) Option[K, V] {
+	var (
+		mu   sync.Mutex
+		stmt *sql.Stmt
+	)
+	prepare := func(ctx context.Context) (*sql.Stmt, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// A failed prepare is retried by the next miss.
+		if stmt == nil {
+			var err error
+			if stmt, err = db.PrepareContext(ctx, query); err != nil {
+				return nil, err
+			}
+		}
+		return stmt, nil
+	}
+
+	return WithLoaderContext(func(ctx context.Context, key K) (V, error) {
+		var zero V
+		stmt, err := prepare(ctx)
+		if err != nil {
+			return zero, err
+		}
+
+		value, err := scanFn(stmt.QueryRowContext(ctx, key))
+		if errors.Is(err, sql.ErrNoRows) {
+			return zero, fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+		return value, err
+	})
+}
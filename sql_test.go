@@ -0,0 +1,66 @@
+package lru
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// go-sqlite3 needs cgo and only reports its absence once used.
+	if err := db.Ping(); err != nil {
+		t.Skipf("sqlite3 is unavailable: %v", err)
+	}
+	// Every connection to :memory: opens its own database.
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE users (id TEXT PRIMARY KEY, name TEXT);
+		INSERT INTO users VALUES ('1', 'Dog'), ('2', 'Cat');`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func scanName(row *sql.Row) (string, error) {
+	var name string
+	err := row.Scan(&name)
+	return name, err
+}
+
+func TestWithSQLLoader(t *testing.T) {
+	db := openTestDB(t)
+	cache := New(3, WithSQLLoader[string](db, "SELECT name FROM users WHERE id = ?", scanName))
+
+	if value, found := cache.Get("1"); !found || value != "Dog" {
+		t.Errorf("Expected (Dog, true), but got: (%q, %t)", value, found)
+	}
+
+	// The value is cached, so changes to the table are not seen.
+	db.Exec("UPDATE users SET name = 'Wolf' WHERE id = '1'")
+	if value, _ := cache.Get("1"); value != "Dog" {
+		t.Errorf("Expected the cached Dog, but got: %q", value)
+	}
+
+	_, err := cache.Fetch("3")
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected ErrNotFound and sql.ErrNoRows for a missing row, but got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, found, err := cache.GetContext(ctx, "2"); found || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled context to stop the query, but got: (%t, %v)", found, err)
+	}
+}
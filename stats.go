@@ -0,0 +1,95 @@
+package lru
+
+import "sync/atomic"
+
+// Stats is a snapshot of the counters a cache keeps about its own usage.
+type Stats struct {
+	// Hits and Misses count Get calls that found, or did not find, a live
+	// entry.
+	Hits   uint64
+	Misses uint64
+	// Evictions counts entries dropped to stay within capacity.
+	Evictions uint64
+	// Insertions counts keys added to the cache.
+	Insertions uint64
+	// GhostHits counts misses on keys recently evicted to make room, which
+	// a larger cache would have found. It is only counted with
+	// WithGhostCache.
+	GhostHits uint64
+
+	// CurrentBytes is the estimated memory taken by the cached entries and
+	// MaxBytes the limit set with WithMaxBytes. Both are 0 without a limit.
+	// Unlike the counters above they are not affected by ResetStats.
+	CurrentBytes int64
+	MaxBytes     int64
+}
+
+// stats holds the live counters behind Stats. They are updated atomically so
+// they can be read without holding any cache lock.
+type stats struct {
+	hits       atomic.Uint64
+	misses     atomic.Uint64
+	evictions  atomic.Uint64
+	insertions atomic.Uint64
+	ghostHits  atomic.Uint64
+
+	bytes    atomic.Int64
+	maxBytes int64
+}
+
+func (s *stats) snapshot() Stats {
+	return Stats{
+		Hits:       s.hits.Load(),
+		Misses:     s.misses.Load(),
+		Evictions:  s.evictions.Load(),
+		Insertions: s.insertions.Load(),
+		GhostHits:  s.ghostHits.Load(),
+
+		CurrentBytes: s.bytes.Load(),
+		MaxBytes:     s.maxBytes,
+	}
+}
+
+func (s *stats) reset() {
+	s.hits.Store(0)
+	s.misses.Store(0)
+	s.evictions.Store(0)
+	s.insertions.Store(0)
+	s.ghostHits.Store(0)
+}
+
+// Stats returns a snapshot of the cache usage counters.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stats.snapshot()
+}
+
+// ResetStats sets every usage counter back to zero.
+func (c *Cache[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.reset()
+}
+
+// HitRate returns the share of Get calls that found a live entry, or 0 when
+// no Get has been made.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// MissRate returns the share of Get calls that did not find a live entry, or
+// 0 when no Get has been made.
+func (s Stats) MissRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Misses) / float64(total)
+}
@@ -0,0 +1,56 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	cache := New[string, int](2)
+
+	cache.Get("Dog")
+	cache.Set("Dog", 1)
+	cache.Set("Cat", 2)
+	cache.Set("Dog", 10)
+	cache.Get("Dog")
+	cache.Get("Dog")
+	cache.Set("Soda", 3)
+	cache.Delete("Soda")
+	cache.Get("Cat")
+
+	expected := Stats{Hits: 2, Misses: 2, Evictions: 1, Insertions: 3}
+	if actual := cache.Stats(); actual != expected {
+		t.Errorf("Expected stats: %+v, but got: %+v", expected, actual)
+	}
+
+	cache.ResetStats()
+	if actual := cache.Stats(); actual != (Stats{}) {
+		t.Errorf("Expected zeroed stats after ResetStats, but got: %+v", actual)
+	}
+}
+
+func TestShardedStats(t *testing.T) {
+	cache, err := NewSharded[string, int](64, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("Element%d", i)
+		cache.Set(key, i)
+		cache.Get(key)
+		cache.Get(key + "-missing")
+	}
+
+	expected := Stats{Hits: 10, Misses: 10, Insertions: 10}
+	if actual := cache.Stats(); actual != expected {
+		t.Errorf("Expected stats: %+v, but got: %+v", expected, actual)
+	}
+}
+
+func TestStatsRatesWithoutLookups(t *testing.T) {
+	var stats Stats
+	if stats.HitRate() != 0 || stats.MissRate() != 0 {
+		t.Errorf("Expected zero rates without lookups, but got: %f and %f", stats.HitRate(), stats.MissRate())
+	}
+}
@@ -0,0 +1,92 @@
+package lru
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// refresh is the outcome of a background revalidation. It is applied by the
+// next lookup, so the cache itself is only ever modified by its callers.
+type refresh[K comparable, V any] struct {
+	node       *Node[K, V]
+	generation uint64
+	value      V
+	err        error
+}
+
+// SetWithSWR stores value under key so that it is fresh for ttl and may then
+// be served stale for a further stale duration. The first Get in the stale
+// window returns the cached value right away and reloads it in the background
+// with the loader configured by WithLoader; the reloaded value is picked up by
+// a later lookup. Once the stale window is over the entry is a miss.
+func (c *Cache[K, V]) SetWithSWR(key K, value V, ttl, stale time.Duration) error {
+	if err := waitWrite(context.Background(), c.limiter); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.write(context.Background(), key, value, ttl, stale, 1)
+}
+
+// revalidate reloads node in the background if it is being served stale and
+// no reload is in flight yet.
+func (c *Cache[K, V]) revalidate(node *Node[K, V]) {
+	if c.loader == nil || node.staleAt.IsZero() || node.revalidating || !c.now().After(node.expiresAt) {
+		return
+	}
+
+	node.revalidating = true
+	node.revalidation++
+	node.refreshes++
+	key, generation := node.Key, node.revalidation
+	go func() {
+		value, err := c.callLoader(context.Background(), key)
+
+		c.refreshMu.Lock()
+		c.refreshed = append(c.refreshed, refresh[K, V]{node: node, generation: generation, value: value, err: err})
+		c.hasRefreshed.Store(true)
+		c.refreshMu.Unlock()
+	}()
+}
+
+// applyRefreshed stores the results of finished background reloads. Results
+// for entries that were removed or overwritten in the meantime, or of a reload
+// which was superseded by a later one, are dropped.
+func (c *Cache[K, V]) applyRefreshed() {
+	if !c.hasRefreshed.Load() {
+		return
+	}
+
+	c.refreshMu.Lock()
+	refreshed := c.refreshed
+	c.refreshed = nil
+	c.hasRefreshed.Store(false)
+	c.refreshMu.Unlock()
+
+	for _, r := range refreshed {
+		r.node.refreshes--
+		if c.Hash[r.node.Key] != r.node || !r.node.revalidating || r.node.revalidation != r.generation {
+			continue
+		}
+
+		r.node.revalidating = false
+		if r.err != nil {
+			c.log(slog.LevelWarn, "lru: revalidating entry failed", "key", r.node.Key, "error", r.err)
+			continue
+		}
+
+		r.node.Value = r.value
+		r.node.resetLifetime(c.now())
+		c.reweigh(r.node, r.node.cost)
+	}
+}
+
+// cancelRevalidation makes the result of a reload in flight for n stale, for
+// when n is overwritten.
+func (n *Node[K, V]) cancelRevalidation() {
+	n.revalidating = false
+	n.revalidation++
+}
@@ -0,0 +1,137 @@
+package lru
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Condition not met within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSetWithSWR(t *testing.T) {
+	var loads atomic.Int32
+	cache := New(2, WithLoader(func(key string) (int, error) {
+		return int(loads.Add(1)) * 100, nil
+	}))
+	clock := newTestClock(cache)
+
+	cache.SetWithSWR("Dog", 1, time.Second, time.Second)
+
+	clock.Advance(500 * time.Millisecond)
+	if value, found := cache.Get("Dog"); !found || value != 1 {
+		t.Errorf("Expected fresh (1, true), but got: (%d, %t)", value, found)
+	}
+	if loads.Load() != 0 {
+		t.Errorf("Expected no reload while fresh, but got: %d", loads.Load())
+	}
+
+	// Within the stale window the old value is served while it reloads.
+	clock.Advance(time.Second)
+	if value, found := cache.Get("Dog"); !found || value != 1 {
+		t.Errorf("Expected stale (1, true), but got: (%d, %t)", value, found)
+	}
+	cache.Get("Dog")
+
+	waitFor(t, func() bool {
+		value, _ := cache.Get("Dog")
+		return value == 100
+	})
+	if loads.Load() != 1 {
+		t.Errorf("Expected a single reload, but got: %d", loads.Load())
+	}
+
+	// The reloaded value starts a new fresh period.
+	clock.Advance(500 * time.Millisecond)
+	if remaining, ok := cache.TTL("Dog"); !ok || remaining != 1500*time.Millisecond {
+		t.Errorf("Expected (1.5s, true) remaining, but got: (%s, %t)", remaining, ok)
+	}
+
+	clock.Advance(3 * time.Second)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected miss past the stale window")
+	}
+}
+
+func TestSetWithSWRSupersededReload(t *testing.T) {
+	releases := []chan struct{}{make(chan struct{}), make(chan struct{})}
+	var loads atomic.Int32
+	cache := New(2, WithLoader(func(key string) (int, error) {
+		n := loads.Add(1)
+		<-releases[n-1]
+		return int(n) * 100, nil
+	}))
+	clock := newTestClock(cache)
+
+	cache.SetWithSWR("Dog", 1, time.Second, time.Second)
+	clock.Advance(1500 * time.Millisecond)
+	cache.Get("Dog")
+	waitFor(t, func() bool { return loads.Load() == 1 })
+
+	// The entry is overwritten and goes stale again while the first reload
+	// is in flight, which starts a second one.
+	cache.SetWithSWR("Dog", 5, time.Second, time.Second)
+	clock.Advance(1500 * time.Millisecond)
+	cache.Get("Dog")
+	waitFor(t, func() bool { return loads.Load() == 2 })
+
+	close(releases[0])
+	waitFor(t, func() bool { return cache.hasRefreshed.Load() })
+	if value, _ := cache.Get("Dog"); value != 5 {
+		t.Errorf("Expected the superseded reload to be dropped, but got: %d", value)
+	}
+
+	close(releases[1])
+	waitFor(t, func() bool {
+		value, _ := cache.Get("Dog")
+		return value == 200
+	})
+}
+
+func TestSetWithSWRFailedReload(t *testing.T) {
+	var loads atomic.Int32
+	cache := New(2, WithLoader(func(key string) (int, error) {
+		loads.Add(1)
+		return 0, errors.New("store is down")
+	}))
+	clock := newTestClock(cache)
+
+	cache.SetWithSWR("Dog", 1, time.Second, time.Second)
+	clock.Advance(1500 * time.Millisecond)
+	cache.Get("Dog")
+
+	waitFor(t, func() bool {
+		return cache.hasRefreshed.Load()
+	})
+
+	// A failed reload keeps serving the stale value and allows another try.
+	if value, found := cache.Get("Dog"); !found || value != 1 {
+		t.Errorf("Expected stale (1, true), but got: (%d, %t)", value, found)
+	}
+	waitFor(t, func() bool {
+		return loads.Load() >= 2
+	})
+}
+
+func TestSetWithSWRWithoutLoader(t *testing.T) {
+	cache := New[string, int](2)
+	clock := newTestClock(cache)
+
+	cache.SetWithSWR("Dog", 1, time.Second, time.Second)
+	clock.Advance(1500 * time.Millisecond)
+
+	if value, found := cache.Get("Dog"); !found || value != 1 {
+		t.Errorf("Expected stale (1, true), but got: (%d, %t)", value, found)
+	}
+}
@@ -0,0 +1,184 @@
+package lru
+
+import (
+	"context"
+	"time"
+)
+
+// SyncCache is a Cache shared between goroutines. Cache guards its state with
+// its own read-write mutex, so SyncCache adds no locking and every method
+// behaves like the Cache method of the same name. It is the shard type of
+// ShardedCache.
+type SyncCache[K comparable, V any] struct {
+	cache *Cache[K, V]
+}
+
+var _ Interface[string, string] = (*SyncCache[string, string])(nil)
+
+// NewSync creates an empty thread-safe cache which holds at most capacity
+// entries. Like New, it raises a capacity which is not positive to 1.
+func NewSync[K comparable, V any](capacity int, opts ...Option[K, V]) *SyncCache[K, V] {
+	return &SyncCache[K, V]{cache: New(capacity, opts...)}
+}
+
+func (s *SyncCache[K, V]) Get(key K) (V, bool) {
+	return s.cache.Get(key)
+}
+
+func (s *SyncCache[K, V]) Fetch(key K) (V, error) {
+	return s.cache.Fetch(key)
+}
+
+func (s *SyncCache[K, V]) GetContext(ctx context.Context, key K) (V, bool, error) {
+	return s.cache.GetContext(ctx, key)
+}
+
+func (s *SyncCache[K, V]) Set(key K, value V) error {
+	return s.cache.Set(key, value)
+}
+
+func (s *SyncCache[K, V]) SetContext(ctx context.Context, key K, value V) error {
+	return s.cache.SetContext(ctx, key, value)
+}
+
+func (s *SyncCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) error {
+	return s.cache.SetWithTTL(key, value, ttl)
+}
+
+func (s *SyncCache[K, V]) SetWithCost(key K, value V, cost int) error {
+	return s.cache.SetWithCost(key, value, cost)
+}
+
+func (s *SyncCache[K, V]) SetWithTags(key K, value V, tags ...string) error {
+	return s.cache.SetWithTags(key, value, tags...)
+}
+
+func (s *SyncCache[K, V]) InvalidateByTag(tag string) int {
+	return s.cache.InvalidateByTag(tag)
+}
+
+func (s *SyncCache[K, V]) SetWithSWR(key K, value V, ttl, stale time.Duration) error {
+	return s.cache.SetWithSWR(key, value, ttl, stale)
+}
+
+func (s *SyncCache[K, V]) Check(key K) {
+	s.cache.Check(key)
+}
+
+func (s *SyncCache[K, V]) GetOrSet(key K, loader func() (V, error)) (V, error) {
+	return s.cache.GetOrSet(key, loader)
+}
+
+func (s *SyncCache[K, V]) SetNX(key K, value V) bool {
+	return s.cache.SetNX(key, value)
+}
+
+func (s *SyncCache[K, V]) Swap(key K, value V) (V, bool) {
+	return s.cache.Swap(key, value)
+}
+
+func (s *SyncCache[K, V]) Delete(key K) bool {
+	return s.cache.Delete(key)
+}
+
+func (s *SyncCache[K, V]) Clear() {
+	s.cache.Clear()
+}
+
+func (s *SyncCache[K, V]) Resize(newCapacity int) error {
+	return s.cache.Resize(newCapacity)
+}
+
+func (s *SyncCache[K, V]) DeleteExpired() int {
+	return s.cache.DeleteExpired()
+}
+
+// StartJanitor starts a goroutine which removes expired entries every
+// interval, holding the write lock while it does. Call the returned function
+// to stop it.
+func (s *SyncCache[K, V]) StartJanitor(interval time.Duration) (stop func()) {
+	return startJanitor(interval, s.DeleteExpired)
+}
+
+func (s *SyncCache[K, V]) Peek(key K) (V, bool) {
+	return s.cache.Peek(key)
+}
+
+func (s *SyncCache[K, V]) Contains(key K) bool {
+	return s.cache.Contains(key)
+}
+
+func (s *SyncCache[K, V]) TTL(key K) (time.Duration, bool) {
+	return s.cache.TTL(key)
+}
+
+func (s *SyncCache[K, V]) Tags(key K) []string {
+	return s.cache.Tags(key)
+}
+
+func (s *SyncCache[K, V]) Oldest() (key K, value V, ok bool) {
+	return s.cache.Oldest()
+}
+
+func (s *SyncCache[K, V]) Newest() (key K, value V, ok bool) {
+	return s.cache.Newest()
+}
+
+func (s *SyncCache[K, V]) Keys() []K {
+	return s.cache.Keys()
+}
+
+func (s *SyncCache[K, V]) Values() []V {
+	return s.cache.Values()
+}
+
+func (s *SyncCache[K, V]) Entries() []Entry[K, V] {
+	return s.cache.Entries()
+}
+
+// Clone returns a SyncCache holding a deep copy of the cache, see
+// Cache.Clone.
+func (s *SyncCache[K, V]) Clone() *SyncCache[K, V] {
+	return &SyncCache[K, V]{cache: s.cache.Clone()}
+}
+
+// Merge behaves like Cache.Merge.
+func (s *SyncCache[K, V]) Merge(other *SyncCache[K, V], strategy MergeStrategy) {
+	s.cache.Merge(other.cache, strategy)
+}
+
+func (s *SyncCache[K, V]) Snapshot() *Snapshot[K, V] {
+	return s.cache.Snapshot()
+}
+
+func (s *SyncCache[K, V]) ToMap() map[K]V {
+	return s.cache.ToMap()
+}
+
+func (s *SyncCache[K, V]) FromMap(m map[K]V) {
+	s.cache.FromMap(m)
+}
+
+func (s *SyncCache[K, V]) ForEach(fn func(key K, value V) bool) {
+	s.cache.ForEach(fn)
+}
+
+func (s *SyncCache[K, V]) Stats() Stats {
+	return s.cache.Stats()
+}
+
+func (s *SyncCache[K, V]) ResetStats() {
+	s.cache.ResetStats()
+}
+
+func (s *SyncCache[K, V]) Cost() int {
+	return s.cache.Cost()
+}
+
+func (s *SyncCache[K, V]) Len() int {
+	return s.cache.Len()
+}
+
+func (s *SyncCache[K, V]) Cap() int {
+	return s.cache.Cap()
+}
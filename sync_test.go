@@ -0,0 +1,120 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSyncCache(t *testing.T) {
+	cache := NewSync[string, int](3)
+
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee"} {
+		cache.Set(e, i)
+	}
+	cache.Get("Cat")
+
+	expectedKeys := []string{"Cat", "Tee", "Soda"}
+	actualKeys := cache.Keys()
+	if !equalSlice(expectedKeys, actualKeys) {
+		t.Errorf("Expected keys: %v, but got: %v", expectedKeys, actualKeys)
+	}
+	if cache.Len() != 3 || cache.Cap() != 3 {
+		t.Errorf("Expected Len 3 and Cap 3, but got: %d and %d", cache.Len(), cache.Cap())
+	}
+}
+
+func TestSyncCacheConcurrentAccess(t *testing.T) {
+	const goroutines = 8
+	const operations = 1000
+
+	cache := NewSync[string, int](64)
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < operations; i++ {
+				key := fmt.Sprintf("Element%d", (g*operations+i)%128)
+				cache.Set(key, i)
+				cache.Get(key)
+				cache.Peek(key)
+				cache.Contains(key)
+				if i%10 == 0 {
+					cache.Delete(key)
+				}
+				cache.Len()
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Expected Len to stay within Cap %d, but got: %d", cache.Cap(), cache.Len())
+	}
+	if len(cache.Keys()) != cache.Len() {
+		t.Errorf("Expected %d keys, but got: %d", cache.Len(), len(cache.Keys()))
+	}
+}
+
+func TestSyncCacheConcurrentCheck(t *testing.T) {
+	const goroutines = 8
+	const operations = 1000
+
+	cache := NewSync[string, string](16)
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < operations; i++ {
+				cache.Check(fmt.Sprintf("Element%d", (g*operations+i)%64))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	checkLinks(t, cache.cache.LinkedList, 16)
+}
+
+func TestCacheConcurrentCheck(t *testing.T) {
+	const goroutines = 8
+	const operations = 1000
+
+	cache := New[string, string](16)
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < operations; i++ {
+				cache.Check(fmt.Sprintf("Element%d", (g*operations+i)%64))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	checkLinks(t, cache.LinkedList, 16)
+}
+
+// checkLinks walks list in both directions to make sure no link was lost.
+func checkLinks[K comparable, V any](t *testing.T, list LinkedList[K, V], length int) {
+	t.Helper()
+
+	forward, backward := 0, 0
+	for node := list.Head.Right; node != list.Tail; node = node.Right {
+		if node.Right.Left != node {
+			t.Fatalf("Expected %v to be linked back from its right neighbour", node.Key)
+		}
+		forward++
+	}
+	for node := list.Tail.Left; node != list.Head; node = node.Left {
+		backward++
+	}
+	if forward != list.Length || backward != list.Length || list.Length != length {
+		t.Errorf("Expected %d linked entries, but got Length %d, %d forward and %d backward", length, list.Length, forward, backward)
+	}
+}
@@ -0,0 +1,91 @@
+package lru
+
+import "context"
+
+// SetWithTags stores value under key like Set and attaches tags to the entry,
+// replacing the tags it had before, so that it can later be removed together
+// with other entries through InvalidateByTag. Set and the other setters keep
+// the tags of an entry which is already cached.
+func (c *Cache[K, V]) SetWithTags(key K, value V, tags ...string) error {
+	if err := waitWrite(context.Background(), c.limiter); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.write(context.Background(), key, value, c.defaultTTL, 0, 1); err != nil {
+		return err
+	}
+
+	c.tag(c.Hash[key], tags)
+	return nil
+}
+
+// Tags returns the tags attached to the entry stored under key, without
+// updating its position.
+func (c *Cache[K, V]) Tags(key K) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node, ok := c.Hash[key]
+	if !ok || !node.live(c.now()) {
+		return nil
+	}
+	return append([]string(nil), node.tags...)
+}
+
+// InvalidateByTag removes every entry tagged with tag and returns how many were
+// removed.
+func (c *Cache[K, V]) InvalidateByTag(tag string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := c.tags[tag]
+	nodes := make([]*Node[K, V], 0, len(keys))
+	for key := range keys {
+		nodes = append(nodes, c.Hash[key])
+	}
+
+	for _, node := range nodes {
+		c.remove(node, removedExplicitly)
+	}
+	return len(nodes)
+}
+
+// tag replaces the tags of node and updates the tag index.
+func (c *Cache[K, V]) tag(node *Node[K, V], tags []string) {
+	c.untag(node)
+	if len(tags) == 0 {
+		return
+	}
+	if c.tags == nil {
+		c.tags = make(map[string]map[K]struct{})
+	}
+
+	node.tags = make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[K]struct{})
+			c.tags[tag] = keys
+		}
+		if _, ok := keys[node.Key]; ok {
+			continue
+		}
+		keys[node.Key] = struct{}{}
+		node.tags = append(node.tags, tag)
+	}
+}
+
+// untag drops node from the tag index, deleting tags left without entries.
+func (c *Cache[K, V]) untag(node *Node[K, V]) {
+	for _, tag := range node.tags {
+		keys := c.tags[tag]
+		delete(keys, node.Key)
+		if len(keys) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+	node.tags = nil
+}
@@ -0,0 +1,61 @@
+package lru
+
+import "testing"
+
+func TestInvalidateByTag(t *testing.T) {
+	cache := New[string, int](4)
+
+	cache.SetWithTags("Dog", 1, "pets", "tenant:a")
+	cache.SetWithTags("Cat", 2, "pets", "tenant:b")
+	cache.SetWithTags("Soda", 3, "drinks", "tenant:a")
+	cache.Set("Tee", 4)
+
+	if removed := cache.InvalidateByTag("tenant:a"); removed != 2 {
+		t.Errorf("Expected 2 entries to be removed, but got: %d", removed)
+	}
+
+	expectedCacheState := []string{"Tee", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+
+	// Removing the entries drops them from their other tags too.
+	if keys := len(cache.tags["pets"]); keys != 1 {
+		t.Errorf("Expected pets to keep only Cat, but got %d keys", keys)
+	}
+	if _, ok := cache.tags["drinks"]; ok {
+		t.Errorf("Expected tags without entries to be dropped from the index")
+	}
+	if removed := cache.InvalidateByTag("missing"); removed != 0 {
+		t.Errorf("Expected nothing to be removed for an unknown tag, but got: %d", removed)
+	}
+}
+
+func TestTagsFollowEviction(t *testing.T) {
+	cache := New[string, int](2)
+
+	cache.SetWithTags("Dog", 1, "pets")
+	cache.SetWithTags("Cat", 2, "pets")
+	cache.Set("Soda", 3)
+
+	if len(cache.tags["pets"]) != 1 {
+		t.Errorf("Expected the evicted entry to leave the tag index, but got: %v", cache.tags["pets"])
+	}
+
+	// SetWithTags replaces the tags, while Set keeps them.
+	cache.SetWithTags("Cat", 20, "felines", "felines")
+	cache.Set("Cat", 21)
+	expectedTags := []string{"felines"}
+	if !equalSlice(expectedTags, cache.Tags("Cat")) {
+		t.Errorf("Expected tags: %v, but got: %v", expectedTags, cache.Tags("Cat"))
+	}
+	if _, ok := cache.tags["pets"]; ok {
+		t.Errorf("Expected replaced tags to be dropped from the index")
+	}
+
+	cache.Clear()
+	if len(cache.tags) != 0 {
+		t.Errorf("Expected Clear to empty the tag index, but got: %v", cache.tags)
+	}
+}
@@ -0,0 +1,254 @@
+// Package tiered combines a small in-memory lru cache with a larger, possibly
+// slower second tier, such as a disk or network backed cache.
+package tiered
+
+import (
+	"errors"
+	"sync"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+)
+
+// Cache is a two level cache. Reads are served from L1 when possible and fall
+// back to L2; entries found in L2 are promoted back to L1. Writes go to L1 and,
+// depending on the options, to L2 right away or once they are evicted from L1.
+// A Cache is safe for concurrent use as long as L2 is.
+type Cache[K comparable, V any] struct {
+	mu sync.Mutex
+	l1 *lru.Cache[K, V]
+	l2 lru.Interface[K, V]
+
+	writeThrough bool
+	writeBack    bool
+
+	// removing is set while entries leave L1 through Delete or Clear, so they
+	// are not written back.
+	removing bool
+
+	// pending holds entries evicted from L1 which are not written to L2 yet.
+	// seq tells a newer eviction of the same key apart from the one being
+	// written. writeMu orders writes to L2 with Delete and Clear.
+	pending map[K]pendingWrite[V]
+	seq     uint64
+	writeMu sync.Mutex
+
+	flush chan struct{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+type pendingWrite[V any] struct {
+	value V
+	seq   uint64
+}
+
+// Option configures a Cache created by New.
+type Option func(*config)
+
+type config struct {
+	writeThrough bool
+	writeBack    bool
+}
+
+// WithWriteThrough makes Set write to L2 as well as L1, before returning.
+func WithWriteThrough(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.writeThrough = enabled
+	}
+}
+
+// WithWriteBack makes entries evicted from L1 to make room be written to L2 by
+// a background goroutine. Until they are written they are still served by
+// Get. Call Close to write the remaining entries and stop the goroutine.
+func WithWriteBack(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.writeBack = enabled
+	}
+}
+
+// New creates a Cache with an L1 holding at most l1Capacity entries in front
+// of l2. Without options, L2 is only read from and entries written by Set
+// never reach it.
+func New[K comparable, V any](l1Capacity int, l2 lru.Interface[K, V], opts ...Option) *Cache[K, V] {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c := &Cache[K, V]{
+		l2:           l2,
+		writeThrough: cfg.writeThrough,
+		writeBack:    cfg.writeBack,
+	}
+
+	var l1Opts []lru.Option[K, V]
+	if c.writeBack {
+		c.pending = map[K]pendingWrite[V]{}
+		c.flush = make(chan struct{}, 1)
+		c.done = make(chan struct{})
+		l1Opts = append(l1Opts, lru.WithOnEvict(c.evicted))
+
+		c.wg.Add(1)
+		go c.writeBackLoop()
+	}
+	c.l1 = lru.New(l1Capacity, l1Opts...)
+
+	return c
+}
+
+// Get returns the value stored under key in L1 or, failing that, L2. An entry
+// found in L2 is stored in L1 as its most recently used entry. L2 is read
+// without holding the lock, so a slow L2 does not block hits in L1.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	if value, ok := c.l1.Get(key); ok {
+		c.mu.Unlock()
+		return value, true
+	}
+	if p, ok := c.pending[key]; ok {
+		c.l1.Set(key, p.value)
+		c.mu.Unlock()
+		return p.value, true
+	}
+	c.mu.Unlock()
+
+	value, ok := c.l2.Get(key)
+	if !ok {
+		return value, false
+	}
+
+	// Do not overwrite a value set while L2 was being read.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.l1.Contains(key) {
+		c.l1.Set(key, value)
+	}
+	return value, true
+}
+
+// Set stores value under key in L1, and in L2 with WithWriteThrough. A write
+// to L2 which fails is returned, but the value stays in L1.
+func (c *Cache[K, V]) Set(key K, value V) error {
+	c.mu.Lock()
+	c.l1.Set(key, value)
+	delete(c.pending, key)
+	c.mu.Unlock()
+
+	if !c.writeThrough {
+		return nil
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return c.l2.Set(key, value)
+}
+
+// Delete removes key from both tiers, including an entry which is waiting to
+// be written back. It reports whether the key was present in either.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.mu.Lock()
+	c.removing = true
+	deleted := c.l1.Delete(key)
+	c.removing = false
+	if _, ok := c.pending[key]; ok {
+		delete(c.pending, key)
+		deleted = true
+	}
+	c.mu.Unlock()
+
+	return c.l2.Delete(key) || deleted
+}
+
+// Clear removes all entries from both tiers.
+func (c *Cache[K, V]) Clear() {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.mu.Lock()
+	c.removing = true
+	c.l1.Clear()
+	c.removing = false
+	clear(c.pending)
+	c.mu.Unlock()
+
+	c.l2.Clear()
+}
+
+// Flush writes the entries waiting to be written back to L2 and returns the
+// errors of the writes which failed. Failed entries stay pending and are
+// retried by the next flush.
+func (c *Cache[K, V]) Flush() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.mu.Lock()
+	batch := make(map[K]pendingWrite[V], len(c.pending))
+	for key, p := range c.pending {
+		batch[key] = p
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for key, p := range batch {
+		if err := c.l2.Set(key, p.value); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// Keep the entry if it was evicted again meanwhile.
+		c.mu.Lock()
+		if c.pending[key].seq == p.seq {
+			delete(c.pending, key)
+		}
+		c.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the write-back goroutine started by WithWriteBack and writes
+// the entries still pending, returning the errors of the writes which failed.
+// The cache must not be used afterwards. Without WithWriteBack it does
+// nothing.
+func (c *Cache[K, V]) Close() error {
+	if !c.writeBack {
+		return nil
+	}
+
+	close(c.done)
+	c.wg.Wait()
+	return c.Flush()
+}
+
+// evicted is the L1 eviction callback queueing entries to be written back.
+// It runs with c.mu held.
+func (c *Cache[K, V]) evicted(key K, value V) {
+	if c.removing {
+		return
+	}
+
+	c.seq++
+	c.pending[key] = pendingWrite[V]{value: value, seq: c.seq}
+
+	select {
+	case c.flush <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Cache[K, V]) writeBackLoop() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.flush:
+			c.Flush()
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package tiered
+
+import (
+	"errors"
+	"testing"
+
+	lru "github.com/Hubert-Madej/go-lru-cache"
+	"github.com/Hubert-Madej/go-lru-cache/cachetest"
+)
+
+func TestGetFallsBackToL2(t *testing.T) {
+	l2 := lru.NewSync[string, string](10)
+	l2.Set("Dog", "Woof")
+	cache := New[string, string](1, l2)
+
+	if value, found := cache.Get("Dog"); !found || value != "Woof" {
+		t.Errorf("Expected (Woof, true) from L2, but got: (%q, %t)", value, found)
+	}
+	if !cache.l1.Contains("Dog") {
+		t.Errorf("Expected an L2 hit to be promoted to L1")
+	}
+	if _, found := cache.Get("Cat"); found {
+		t.Errorf("Expected a miss in both tiers")
+	}
+
+	// Without write-through or write-back, Set only reaches L1.
+	cache.Set("Cat", "Meow")
+	if l2.Contains("Cat") {
+		t.Errorf("Expected Set not to write to L2 by default")
+	}
+}
+
+func TestWriteThrough(t *testing.T) {
+	l2 := cachetest.NewMock[string, string]()
+	cache := New[string, string](1, l2, WithWriteThrough(true))
+
+	if err := cache.Set("Dog", "Woof"); err != nil {
+		t.Fatal(err)
+	}
+	l2.AssertCalled(t, "Set", "Dog", "Woof")
+
+	if !cache.Delete("Dog") || l2.Contains("Dog") || cache.l1.Contains("Dog") {
+		t.Errorf("Expected Delete to remove Dog from both tiers")
+	}
+}
+
+func TestWriteBack(t *testing.T) {
+	l2 := lru.NewSync[string, string](10)
+	cache := New[string, string](1, l2, WithWriteBack(true))
+
+	cache.Set("Dog", "Woof")
+	cache.Set("Cat", "Meow")
+
+	// Dog was evicted from L1; it is served whether or not it reached L2.
+	if value, found := cache.Get("Dog"); !found || value != "Woof" {
+		t.Errorf("Expected (Woof, true) after eviction, but got: (%q, %t)", value, found)
+	}
+
+	// Deleted entries are not written back, even while pending.
+	cache.Set("Soda", "Fizz")
+	cache.Set("Tee", "Hot")
+	cache.Delete("Soda")
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"Dog", "Cat"} {
+		if !l2.Contains(key) {
+			t.Errorf("Expected %s to be written back to L2", key)
+		}
+	}
+	if l2.Contains("Soda") || l2.Contains("Tee") {
+		t.Errorf("Expected only evicted entries to be written back, but got L2 keys: %v", l2.Keys())
+	}
+}
+
+// failingL2 rejects every write.
+type failingL2 struct {
+	*cachetest.Mock[string, string]
+}
+
+var errL2Down = errors.New("l2 down")
+
+func (failingL2) Set(key, value string) error {
+	return errL2Down
+}
+
+func TestWriteBackFailure(t *testing.T) {
+	cache := New[string, string](1, failingL2{cachetest.NewMock[string, string]()}, WithWriteBack(true))
+
+	cache.Set("Dog", "Woof")
+	cache.Set("Cat", "Meow")
+
+	if err := cache.Close(); !errors.Is(err, errL2Down) {
+		t.Errorf("Expected the failed write-back to be reported, but got: %v", err)
+	}
+	if value, found := cache.Get("Dog"); !found || value != "Woof" {
+		t.Errorf("Expected a failed write-back to stay pending, but got: (%q, %t)", value, found)
+	}
+}
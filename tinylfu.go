@@ -0,0 +1,124 @@
+package lru
+
+import "github.com/Hubert-Madej/go-lru-cache/sketch"
+
+// PolicyWTinyLFU is the W-TinyLFU policy used by Caffeine and Ristretto. New
+// entries enter a small LRU window holding 1% of the capacity. Entries pushed
+// out of the window compete with the eviction candidate of the main cache, a
+// segmented LRU: the one with the higher estimated access frequency stays.
+// Frequencies are estimated with a Count-Min Sketch which is halved
+// periodically, so old popularity fades.
+var PolicyWTinyLFU = EvictionPolicy{kind: policyWTinyLFU}
+
+const (
+	tinyLFUWindowRatio    = 0.01
+	tinyLFUProtectedRatio = 0.8
+)
+
+type tinyLFUPolicy[K comparable, V any] struct {
+	capacity *int
+
+	// sketch is sized for sized entries. It is replaced when the capacity
+	// changes, see frequencies.
+	sketch *sketch.CountMinSketch
+	sized  int
+
+	window    *segment[K, V]
+	probation *segment[K, V]
+	protected *segment[K, V]
+}
+
+func newTinyLFUPolicy[K comparable, V any](capacity *int) *tinyLFUPolicy[K, V] {
+	return &tinyLFUPolicy[K, V]{
+		capacity:  capacity,
+		sketch:    sketch.New(*capacity),
+		sized:     *capacity,
+		window:    newSegment[K, V](),
+		probation: newSegment[K, V](),
+		protected: newSegment[K, V](),
+	}
+}
+
+// frequencies returns the sketch, replaced by an empty one sized for the
+// current capacity when it was changed by Resize. A sketch sized for a smaller
+// cache would count every key as popular, and one sized for a larger cache
+// would fade old popularity too slowly.
+func (p *tinyLFUPolicy[K, V]) frequencies() *sketch.CountMinSketch {
+	if p.sized != *p.capacity {
+		p.sketch = sketch.New(*p.capacity)
+		p.sized = *p.capacity
+	}
+	return p.sketch
+}
+
+func (p *tinyLFUPolicy[K, V]) add(node *Node[K, V]) {
+	p.frequencies().AddHash(hashKey(node.Key))
+	p.window.pushFront(node)
+
+	// Entries leaving the window join the main cache on probation. When the
+	// cache was full, victim already made room for them.
+	for p.window.len() > p.windowLimit() {
+		candidate := p.window.back()
+		p.window.remove(candidate)
+		p.probation.pushFront(candidate)
+	}
+}
+
+func (p *tinyLFUPolicy[K, V]) access(node *Node[K, V]) bool {
+	p.frequencies().AddHash(hashKey(node.Key))
+
+	switch {
+	case p.window.contains(node):
+		p.window.moveToFront(node)
+	case p.probation.remove(node):
+		p.protected.pushFront(node)
+		if p.protected.len() > p.protectedLimit() {
+			demoted := p.protected.back()
+			p.protected.remove(demoted)
+			p.probation.pushFront(demoted)
+		}
+	default:
+		p.protected.moveToFront(node)
+	}
+	return true
+}
+
+func (p *tinyLFUPolicy[K, V]) remove(node *Node[K, V]) {
+	if !p.window.remove(node) && !p.probation.remove(node) {
+		p.protected.remove(node)
+	}
+}
+
+// victim decides between the least recently used entry of a full window,
+// the candidate, which is about to be pushed out by incoming, and the entry
+// the main cache would evict for it. The candidate is only admitted to the
+// main cache if it is used more often.
+func (p *tinyLFUPolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	mainVictim := p.probation.back()
+	if mainVictim == nil {
+		mainVictim = p.protected.back()
+	}
+
+	candidate := p.window.back()
+	frequencies := p.frequencies()
+	if mainVictim == nil || (incoming != nil && p.window.len() >= p.windowLimit() &&
+		frequencies.CountHash(hashKey(candidate.Key)) <= frequencies.CountHash(hashKey(mainVictim.Key))) {
+		return candidate
+	}
+	return mainVictim
+}
+
+func (p *tinyLFUPolicy[K, V]) clear() {
+	p.sketch.Reset()
+	p.window.clear()
+	p.probation.clear()
+	p.protected.clear()
+}
+
+func (p *tinyLFUPolicy[K, V]) windowLimit() int {
+	return max(1, int(tinyLFUWindowRatio*float64(*p.capacity)))
+}
+
+func (p *tinyLFUPolicy[K, V]) protectedLimit() int {
+	return max(1, int(tinyLFUProtectedRatio*float64(*p.capacity-p.windowLimit())))
+}
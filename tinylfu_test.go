@@ -0,0 +1,92 @@
+package lru
+
+import "testing"
+
+func TestPolicyWTinyLFU(t *testing.T) {
+	cache := New(100, WithEvictionPolicy[int, int](PolicyWTinyLFU))
+
+	for i := 0; i < 100; i++ {
+		cache.Set(i, i)
+	}
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 50; i++ {
+			cache.Get(i)
+		}
+	}
+
+	// Entries seen once are rejected by the admission filter, so a scan
+	// does not flush the popular half of the cache.
+	for i := 1000; i < 2000; i++ {
+		cache.Set(i, i)
+	}
+
+	for i := 0; i < 50; i++ {
+		if !cache.Contains(i) {
+			t.Fatalf("Expected popular entry %d to survive a scan", i)
+		}
+	}
+	if cache.Len() != 100 {
+		t.Errorf("Expected Len 100, but got: %d", cache.Len())
+	}
+}
+
+func TestPolicyWTinyLFUResize(t *testing.T) {
+	cache := New(4, WithEvictionPolicy[int, int](PolicyWTinyLFU))
+	policy := cache.policy.(*tinyLFUPolicy[int, int])
+	if err := cache.Resize(1000); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+	if policy.sized != 1000 {
+		t.Errorf("Expected the sketch to be sized for 1000 entries after growing, but got: %d", policy.sized)
+	}
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 500; i++ {
+			cache.Get(i)
+		}
+	}
+
+	// Entries seen once are still rejected by the admission filter.
+	for i := 10000; i < 20000; i++ {
+		cache.Set(i, i)
+	}
+	for i := 0; i < 500; i++ {
+		if !cache.Contains(i) {
+			t.Fatalf("Expected popular entry %d to survive a scan after Resize", i)
+		}
+	}
+
+	if err := cache.Resize(10); err != nil {
+		t.Fatal(err)
+	}
+	cache.Set(-1, -1)
+	if policy.sized != 10 {
+		t.Errorf("Expected the sketch to be sized for 10 entries after shrinking, but got: %d", policy.sized)
+	}
+}
+
+func TestPolicyWTinyLFUAdmitsPopular(t *testing.T) {
+	cache := New(10, WithEvictionPolicy[string, int](PolicyWTinyLFU))
+	policy := cache.policy.(*tinyLFUPolicy[string, int])
+
+	for _, key := range []string{"Dog", "Cat", "Soda", "Tee", "Car", "Terry", "Apple", "Banana", "Grape", "Pineapple"} {
+		cache.Set(key, 0)
+	}
+
+	// A key which keeps coming back is eventually admitted over entries seen
+	// only once.
+	for i := 0; i < 5; i++ {
+		cache.Set("Watermelon", i)
+		cache.Set("Melon", i)
+	}
+
+	if !cache.Contains("Watermelon") {
+		t.Fatalf("Expected a repeatedly requested key to be cached")
+	}
+	if policy.window.len()+policy.probation.len()+policy.protected.len() != cache.Len() {
+		t.Errorf("Expected the segments to hold all %d entries", cache.Len())
+	}
+}
@@ -0,0 +1,71 @@
+package lru
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TopK returns the k live entries with the highest AccessCount, from the most
+// accessed one, without updating their positions. Entries accessed equally
+// often are ordered from the most recently used one. When k is larger than the
+// number of entries, all of them are returned.
+func (c *Cache[K, V]) TopK(k int) []Entry[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.rank(k, false)
+}
+
+// BottomK is the counterpart of TopK, returning the k least accessed entries
+// from the least accessed one. Entries accessed equally often are ordered
+// from the least recently used one.
+func (c *Cache[K, V]) BottomK(k int) []Entry[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.rank(k, true)
+}
+
+// rank returns the k most, or if coldest is set least, accessed live entries.
+func (c *Cache[K, V]) rank(k int, coldest bool) []Entry[K, V] {
+	if k <= 0 {
+		return nil
+	}
+
+	// Collect the nodes in the order ties are reported in, since the sort is
+	// stable.
+	now := c.now()
+	nodes := make([]*Node[K, V], 0, c.LinkedList.Length)
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		if node.live(now) {
+			nodes = append(nodes, node)
+		}
+	}
+	if coldest {
+		slices.Reverse(nodes)
+	}
+
+	slices.SortStableFunc(nodes, func(a, b *Node[K, V]) int {
+		if coldest {
+			return cmp.Compare(a.AccessCount, b.AccessCount)
+		}
+		return cmp.Compare(b.AccessCount, a.AccessCount)
+	})
+
+	nodes = nodes[:min(k, len(nodes))]
+	entries := make([]Entry[K, V], len(nodes))
+	for i, node := range nodes {
+		entries[i] = Entry[K, V]{Key: node.Key, Value: node.Value}
+	}
+	return entries
+}
+
+// TopK behaves like Cache.TopK.
+func (s *SyncCache[K, V]) TopK(k int) []Entry[K, V] {
+	return s.cache.TopK(k)
+}
+
+// BottomK behaves like Cache.BottomK.
+func (s *SyncCache[K, V]) BottomK(k int) []Entry[K, V] {
+	return s.cache.BottomK(k)
+}
@@ -0,0 +1,51 @@
+package lru
+
+import "testing"
+
+func entryKeys[K comparable, V any](entries []Entry[K, V]) []K {
+	keys := make([]K, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+func TestTopK(t *testing.T) {
+	cache := New[string, int](5)
+	for i, e := range []string{"Dog", "Cat", "Soda", "Tee", "Terry"} {
+		cache.Set(e, i)
+	}
+
+	for i := 0; i < 3; i++ {
+		cache.Get("Soda")
+	}
+	cache.Get("Dog")
+	cache.Check("Dog")
+	cache.Get("Cat")
+	cache.Peek("Tee")
+
+	expectedTop := []string{"Soda", "Dog"}
+	if actual := entryKeys(cache.TopK(2)); !equalSlice(expectedTop, actual) {
+		t.Errorf("Expected top keys: %v, but got: %v", expectedTop, actual)
+	}
+
+	// Ties are broken by recency: Terry was used more recently than Tee.
+	expectedBottom := []string{"Tee", "Terry", "Cat"}
+	if actual := entryKeys(cache.BottomK(3)); !equalSlice(expectedBottom, actual) {
+		t.Errorf("Expected bottom keys: %v, but got: %v", expectedBottom, actual)
+	}
+
+	if all := cache.TopK(10); len(all) != 5 {
+		t.Errorf("Expected all 5 entries, but got: %d", len(all))
+	}
+	if none := cache.TopK(0); len(none) != 0 {
+		t.Errorf("Expected no entries, but got: %v", none)
+	}
+
+	// Reading the ranking does not touch the recently used order.
+	expectedCacheState := []string{"Cat", "Dog", "Soda", "Terry", "Tee"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
@@ -0,0 +1,195 @@
+package lru
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SetWithTTL stores value under key like Set, but the entry expires once ttl
+// has elapsed. Expired entries are dropped lazily, the next time they are
+// looked up. A ttl of 0 means the entry never expires. See WithSlidingTTL for
+// restarting the lifetime on every read.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) error {
+	if err := waitWrite(context.Background(), c.limiter); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.write(context.Background(), key, value, ttl, 0, 1)
+}
+
+// TTL returns how long the entry stored under key has left to live, without
+// updating its position. For entries stored with SetWithSWR this includes the
+// stale window. It returns -1 and true for an entry that never
+// expires, and 0 and false for a missing or expired entry.
+func (c *Cache[K, V]) TTL(key K) (time.Duration, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node, ok := c.Hash[key]
+	if !ok {
+		return 0, false
+	}
+
+	deadline := node.deadline()
+	if deadline.IsZero() {
+		return -1, true
+	}
+
+	now := c.now()
+	if !node.live(now) {
+		return 0, false
+	}
+	return deadline.Sub(now), true
+}
+
+// DeleteExpired removes every expired entry and returns how many were removed.
+func (c *Cache[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	var expired []*Node[K, V]
+	for node := c.LinkedList.Head.Right; node != c.LinkedList.Tail; node = node.Right {
+		if node.expired(now) {
+			expired = append(expired, node)
+		}
+	}
+
+	for _, node := range expired {
+		c.remove(node, removedExpired)
+	}
+	return len(expired)
+}
+
+func (c *Cache[K, V]) set(key K, value V, ttl, stale time.Duration, cost int) {
+	node, ok := c.Hash[key]
+	if !ok {
+		node = c.newNode()
+		node.Key, node.Value = key, value
+		node.ttl, node.stale, node.cost = ttl, stale, cost
+		node.resetLifetime(c.now())
+		c.insert(node)
+		return
+	}
+
+	old := node.Value
+	node.Value = value
+	node.cancelRevalidation()
+	node.negative = false
+	node.ttl, node.stale = ttl, stale
+	node.resetLifetime(c.now())
+	c.promote(node)
+	c.reweigh(node, cost)
+	c.notifyChange(ChangeSet, key, old, value)
+}
+
+// lookup returns the live node stored under key. An expired node is removed
+// and reported as a miss.
+func (c *Cache[K, V]) lookup(key K) (*Node[K, V], bool) {
+	node, err := c.find(key)
+	if err != nil {
+		return nil, false
+	}
+	return node, true
+}
+
+// find is lookup reporting ErrNotFound or ErrExpired for a miss. A negative
+// node is returned along with errCachedNotFound.
+func (c *Cache[K, V]) find(key K) (*Node[K, V], error) {
+	c.applyRefreshed()
+
+	if c.bloom != nil && !c.bloom.mayContain(hashKey(key)) {
+		return nil, ErrNotFound
+	}
+
+	node, ok := c.Hash[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	if node.expired(c.now()) {
+		c.remove(node, removedExpired)
+		return nil, ErrExpired
+	}
+	if node.negative {
+		return node, errCachedNotFound
+	}
+
+	return node, nil
+}
+
+// touch restarts the lifetime of node when sliding TTL is enabled.
+func (c *Cache[K, V]) touch(node *Node[K, V]) {
+	if c.slidingTTL && node.ttl > 0 {
+		node.resetLifetime(c.now())
+	}
+}
+
+// resetLifetime starts the ttl and stale window of node over from now.
+func (n *Node[K, V]) resetLifetime(now time.Time) {
+	n.expiresAt, n.staleAt = time.Time{}, time.Time{}
+	if n.ttl <= 0 {
+		return
+	}
+
+	n.expiresAt = now.Add(n.ttl)
+	if n.stale > 0 {
+		n.staleAt = n.expiresAt.Add(n.stale)
+	}
+}
+
+// deadline returns the time after which node can no longer be served, or the
+// zero time if it never expires.
+func (n *Node[K, V]) deadline() time.Time {
+	if !n.staleAt.IsZero() {
+		return n.staleAt
+	}
+	return n.expiresAt
+}
+
+// live reports whether node can be returned by a lookup at now, which
+// expired and negative nodes cannot.
+func (n *Node[K, V]) live(now time.Time) bool {
+	return !n.negative && !n.expired(now)
+}
+
+func (n *Node[K, V]) expired(now time.Time) bool {
+	deadline := n.deadline()
+	return !deadline.IsZero() && now.After(deadline)
+}
+
+// startJanitor calls deleteExpired every interval from a new goroutine. The
+// returned function stops the goroutine and waits for it to exit; calling it
+// more than once is safe.
+func startJanitor(interval time.Duration, deleteExpired func() int) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				deleteExpired()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-exited
+		})
+	}
+}
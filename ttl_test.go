@@ -0,0 +1,275 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock replaces the cache clock so expiration can be tested without
+// sleeping.
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.now = f.now.Add(d)
+}
+
+func newTestClock[K comparable, V any](cache *Cache[K, V]) *fakeClock {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache.now = clock.Now
+	return clock
+}
+
+func TestSetWithTTL(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", 1, time.Second)
+	cache.Set("Cat", 2)
+
+	clock.Advance(500 * time.Millisecond)
+	if value, found := cache.Get("Dog"); !found || value != 1 {
+		t.Errorf("Expected (1, true) before expiry, but got: (%d, %t)", value, found)
+	}
+
+	clock.Advance(time.Second)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Contains to report expired entry as missing")
+	}
+	if _, found := cache.Peek("Dog"); found {
+		t.Errorf("Expected Peek to report expired entry as missing")
+	}
+	if _, found := cache.Get("Dog"); found {
+		t.Errorf("Expected Get to report expired entry as missing")
+	}
+	if cache.Len() != 1 || len(cache.Hash) != 1 {
+		t.Errorf("Expected expired entry to be removed on Get, but got Len %d", cache.Len())
+	}
+
+	// Entries without a TTL never expire.
+	clock.Advance(time.Hour)
+	if value, found := cache.Get("Cat"); !found || value != 2 {
+		t.Errorf("Expected (2, true), but got: (%d, %t)", value, found)
+	}
+}
+
+func TestSlidingTTL(t *testing.T) {
+	cache := New(3, WithSlidingTTL[string, int]())
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", 1, time.Second)
+	cache.SetWithTTL("Cat", 2, time.Second)
+
+	// Reading Dog every 600ms keeps it alive well past its original deadline.
+	for i := 0; i < 5; i++ {
+		clock.Advance(600 * time.Millisecond)
+		if _, found := cache.Get("Dog"); !found {
+			t.Fatalf("Expected Dog to stay alive while being read, expired after %d reads", i)
+		}
+	}
+
+	if cache.Contains("Cat") {
+		t.Errorf("Expected Cat to expire without reads")
+	}
+
+	clock.Advance(2 * time.Second)
+	if _, found := cache.Get("Dog"); found {
+		t.Errorf("Expected Dog to expire once reads stop")
+	}
+}
+
+func TestFixedTTLWithoutSliding(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", 1, time.Second)
+	clock.Advance(600 * time.Millisecond)
+	cache.Get("Dog")
+	clock.Advance(600 * time.Millisecond)
+
+	if _, found := cache.Get("Dog"); found {
+		t.Errorf("Expected reads not to extend the TTL without WithSlidingTTL")
+	}
+}
+
+func TestDefaultTTL(t *testing.T) {
+	cache := New(3, WithTTL[string, int](time.Second))
+	clock := newTestClock(cache)
+
+	cache.Set("Dog", 1)
+	cache.SetWithTTL("Cat", 2, time.Minute)
+	cache.SetWithTTL("Soda", 3, 0)
+
+	clock.Advance(2 * time.Second)
+	if cache.Contains("Dog") {
+		t.Errorf("Expected Set to use the default TTL")
+	}
+	if !cache.Contains("Cat") || !cache.Contains("Soda") {
+		t.Errorf("Expected SetWithTTL to override the default TTL")
+	}
+}
+
+func TestSetClearsTTL(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", 1, time.Second)
+	cache.Set("Dog", 2)
+
+	clock.Advance(time.Hour)
+	if value, found := cache.Get("Dog"); !found || value != 2 {
+		t.Errorf("Expected Set to clear the TTL, but got: (%d, %t)", value, found)
+	}
+}
+
+func TestCheckExpiredEntry(t *testing.T) {
+	cache := New[string, string](3)
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", "Woof", time.Second)
+	clock.Advance(2 * time.Second)
+	cache.Check("Dog")
+
+	// Check on an expired key inserts a fresh entry instead of reviving the
+	// stale value.
+	if value, found := cache.Get("Dog"); !found || value != "" {
+		t.Errorf("Expected fresh entry with empty value, but got: (%q, %t)", value, found)
+	}
+}
+
+func TestTTL(t *testing.T) {
+	cache := New[string, int](3)
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", 1, time.Second)
+	cache.Set("Cat", 2)
+
+	previous := time.Duration(1<<63 - 1)
+	for i := 0; i < 5; i++ {
+		remaining, ok := cache.TTL("Dog")
+		if !ok || remaining <= 0 || remaining >= previous {
+			t.Fatalf("Expected remaining TTL to decrease below %s, but got: (%s, %t)", previous, remaining, ok)
+		}
+		previous = remaining
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	if remaining, ok := cache.TTL("Cat"); !ok || remaining != -1 {
+		t.Errorf("Expected (-1, true) for entry without TTL, but got: (%s, %t)", remaining, ok)
+	}
+	if remaining, ok := cache.TTL("Soda"); ok || remaining != 0 {
+		t.Errorf("Expected (0, false) for missing entry, but got: (%s, %t)", remaining, ok)
+	}
+
+	clock.Advance(time.Second)
+	if remaining, ok := cache.TTL("Dog"); ok || remaining != 0 {
+		t.Errorf("Expected (0, false) for expired entry, but got: (%s, %t)", remaining, ok)
+	}
+
+	// TTL must not promote Dog ahead of Cat.
+	expectedCacheState := []string{"Cat", "Dog"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestDeleteExpired(t *testing.T) {
+	cache := New[string, int](4)
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", 1, time.Second)
+	cache.SetWithTTL("Cat", 2, time.Minute)
+	cache.SetWithTTL("Soda", 3, time.Second)
+	cache.Set("Tee", 4)
+
+	clock.Advance(2 * time.Second)
+	if removed := cache.DeleteExpired(); removed != 2 {
+		t.Errorf("Expected 2 expired entries to be removed, but got: %d", removed)
+	}
+
+	expectedCacheState := []string{"Tee", "Cat"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+}
+
+func TestOnEvictExpired(t *testing.T) {
+	var evicted []string
+	cache := New(3, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	}))
+	clock := newTestClock(cache)
+
+	cache.SetWithTTL("Dog", 1, time.Second)
+	cache.SetWithTTL("Cat", 2, time.Second)
+	clock.Advance(2 * time.Second)
+
+	cache.Get("Dog")
+	cache.DeleteExpired()
+
+	expectedEvicted := []string{"Dog", "Cat"}
+	if !equalSlice(expectedEvicted, evicted) {
+		t.Errorf("Expected evicted keys: %v, but got: %v", expectedEvicted, evicted)
+	}
+}
+
+func TestStartJanitor(t *testing.T) {
+	cache := NewSync[string, int](4)
+	cache.SetWithTTL("Dog", 1, time.Millisecond)
+	cache.Set("Cat", 2)
+
+	stop := cache.StartJanitor(5 * time.Millisecond)
+	defer stop()
+
+	deadline := time.Now().Add(time.Second)
+	for cache.Len() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected janitor to remove expired entry, but Len is still: %d", cache.Len())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	stop()
+	stop()
+	if !cache.Contains("Cat") {
+		t.Errorf("Expected entry without TTL to survive the janitor")
+	}
+}
+
+func TestExpiryChannel(t *testing.T) {
+	expired := make(chan string, 1)
+	cache := New(3, WithExpiryChannel[string, int](expired))
+
+	cache.SetWithTTL("Dog", 1, 10*time.Millisecond)
+	cache.Set("Cat", 2)
+	time.Sleep(20 * time.Millisecond)
+	cache.Get("Dog")
+
+	select {
+	case key := <-expired:
+		if key != "Dog" {
+			t.Errorf("Expected Dog to be sent, but got: %s", key)
+		}
+	default:
+		t.Errorf("Expected the expired key to be sent to the channel")
+	}
+
+	// Deleted entries are not reported, and a full channel does not block.
+	cache.Delete("Cat")
+	cache.SetWithTTL("Soda", 3, time.Millisecond)
+	cache.SetWithTTL("Tee", 4, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if removed := cache.DeleteExpired(); removed != 2 {
+		t.Errorf("Expected 2 expired entries to be removed, but got: %d", removed)
+	}
+	if len(expired) != 1 {
+		t.Errorf("Expected one key in the channel, but got: %d", len(expired))
+	}
+}
@@ -0,0 +1,92 @@
+package lru
+
+// Default partition of the cache capacity used by PolicyTwoQueue.
+const (
+	DefaultTwoQueueInRatio    = 0.25
+	DefaultTwoQueueGhostRatio = 0.5
+)
+
+// PolicyTwoQueue protects frequently used entries from scans with the 2Q
+// algorithm. New entries land in a FIFO In queue which gets a quarter of the
+// capacity; a second access promotes them to an LRU Out queue holding the
+// rest. Entries evicted from In leave their key in a ghost list, so an entry
+// which comes back soon after goes straight to Out. Entries are only evicted
+// from Out while In is within its share.
+var PolicyTwoQueue = PolicyTwoQueueWithRatios(DefaultTwoQueueInRatio, DefaultTwoQueueGhostRatio)
+
+// PolicyTwoQueueWithRatios is PolicyTwoQueue with In getting inRatio of the
+// capacity and the ghost list remembering up to ghostRatio times the capacity
+// in keys. Ratios outside of (0, 1) are replaced by the defaults.
+func PolicyTwoQueueWithRatios(inRatio, ghostRatio float64) EvictionPolicy {
+	if inRatio <= 0 || inRatio >= 1 {
+		inRatio = DefaultTwoQueueInRatio
+	}
+	if ghostRatio <= 0 || ghostRatio >= 1 {
+		ghostRatio = DefaultTwoQueueGhostRatio
+	}
+	return EvictionPolicy{kind: policyTwoQueue, ratio: inRatio, ghostRatio: ghostRatio}
+}
+
+type twoQueuePolicy[K comparable, V any] struct {
+	capacity   *int
+	inRatio    float64
+	ghostRatio float64
+
+	in    *segment[K, V]
+	out   *segment[K, V]
+	ghost *ghostList[K]
+}
+
+func newTwoQueuePolicy[K comparable, V any](capacity *int, inRatio, ghostRatio float64) *twoQueuePolicy[K, V] {
+	return &twoQueuePolicy[K, V]{
+		capacity:   capacity,
+		inRatio:    inRatio,
+		ghostRatio: ghostRatio,
+		in:         newSegment[K, V](),
+		out:        newSegment[K, V](),
+		ghost:      newGhostList[K](),
+	}
+}
+
+func (p *twoQueuePolicy[K, V]) add(node *Node[K, V]) {
+	if p.ghost.remove(node.Key) {
+		p.out.pushFront(node)
+		return
+	}
+	p.in.pushFront(node)
+}
+
+func (p *twoQueuePolicy[K, V]) access(node *Node[K, V]) bool {
+	if p.in.remove(node) {
+		p.out.pushFront(node)
+	} else {
+		p.out.moveToFront(node)
+	}
+	return true
+}
+
+func (p *twoQueuePolicy[K, V]) remove(node *Node[K, V]) {
+	if !p.in.remove(node) {
+		p.out.remove(node)
+	}
+}
+
+func (p *twoQueuePolicy[K, V]) victim(incoming *Node[K, V]) *Node[K, V] {
+	if p.in.len() > p.limit(p.inRatio) || p.out.len() == 0 {
+		node := p.in.back()
+		p.ghost.push(node.Key, p.limit(p.ghostRatio))
+		return node
+	}
+	return p.out.back()
+}
+
+func (p *twoQueuePolicy[K, V]) clear() {
+	p.in.clear()
+	p.out.clear()
+	p.ghost.clear()
+}
+
+// limit returns ratio of the cache capacity, but at least one entry.
+func (p *twoQueuePolicy[K, V]) limit(ratio float64) int {
+	return max(1, int(ratio*float64(*p.capacity)))
+}
@@ -0,0 +1,103 @@
+package lru
+
+import "testing"
+
+func TestPolicyTwoQueueScan(t *testing.T) {
+	cache := New(100, WithEvictionPolicy[int, int](PolicyTwoQueue))
+
+	cache.Set(-1, -1)
+	for i := 0; i < 50; i++ {
+		cache.Get(-1)
+	}
+
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+
+	if !cache.Contains(-1) {
+		t.Errorf("Expected hot entry to survive a scan of 1000 unique keys")
+	}
+	if cache.Len() != 100 {
+		t.Errorf("Expected Len 100, but got: %d", cache.Len())
+	}
+}
+
+func TestPolicyTwoQueueScanWithLRU(t *testing.T) {
+	cache := New[int, int](100)
+
+	cache.Set(-1, -1)
+	for i := 0; i < 50; i++ {
+		cache.Get(-1)
+	}
+
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+
+	// Plain LRU is what 2Q improves on.
+	if cache.Contains(-1) {
+		t.Errorf("Expected LRU to lose the hot entry to the scan")
+	}
+}
+
+func TestPolicyTwoQueueGhost(t *testing.T) {
+	cache := New(4, WithEvictionPolicy[string, int](PolicyTwoQueue))
+	policy := cache.policy.(*twoQueuePolicy[string, int])
+
+	cache.Set("Dog", 1)
+	cache.Get("Dog")
+	for _, key := range []string{"Cat", "Soda", "Tee", "Car"} {
+		cache.Set(key, 0)
+	}
+
+	// Cat was pushed out of In and is remembered by the ghost list.
+	if cache.Contains("Cat") || !policy.ghost.contains("Cat") {
+		t.Fatalf("Expected Cat to be evicted into the ghost list")
+	}
+
+	// When it comes back it is admitted straight into Out.
+	cache.Set("Cat", 2)
+	if !policy.out.contains(cache.Hash["Cat"]) {
+		t.Errorf("Expected a ghost hit to be admitted into Out")
+	}
+	if policy.ghost.contains("Cat") {
+		t.Errorf("Expected the ghost entry to be forgotten once readmitted")
+	}
+}
+
+func TestPolicyTwoQueueRatios(t *testing.T) {
+	cache := New(10, WithEvictionPolicy[int, int](PolicyTwoQueueWithRatios(0.5, 0.2)))
+	policy := cache.policy.(*twoQueuePolicy[int, int])
+
+	for i := 0; i < 6; i++ {
+		cache.Set(i, i)
+		cache.Get(i)
+	}
+	for i := 6; i < 30; i++ {
+		cache.Set(i, i)
+	}
+
+	// While In is within its half of the capacity, Out gives up its least
+	// recently used entries; after that the scan only churns In.
+	if cache.Contains(0) || cache.Contains(1) {
+		t.Errorf("Expected Out to evict while In is within its share")
+	}
+	for i := 2; i < 6; i++ {
+		if !cache.Contains(i) {
+			t.Errorf("Expected %d to stay in Out", i)
+		}
+	}
+	if policy.out.len() != 4 || policy.in.len() != 6 {
+		t.Errorf("Expected 4 entries in Out and 6 in In, but got: %d and %d", policy.out.len(), policy.in.len())
+	}
+	if policy.ghost.len() != 2 {
+		t.Errorf("Expected the ghost list to be capped at 2 keys, but got: %d", policy.ghost.len())
+	}
+}
+
+func TestPolicyTwoQueueInvalidRatios(t *testing.T) {
+	p := PolicyTwoQueueWithRatios(0, 1.5)
+	if p != PolicyTwoQueue {
+		t.Errorf("Expected invalid ratios to fall back to the defaults, but got: %+v", p)
+	}
+}
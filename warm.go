@@ -0,0 +1,110 @@
+package lru
+
+import "context"
+
+// WarmSource supplies the entries Warm preloads a cache with. Entries sends
+// them from the most to the least important one and closes the channel when
+// done. It should stop sending once ctx is done.
+type WarmSource[K comparable, V any] interface {
+	Entries(ctx context.Context) (<-chan Entry[K, V], error)
+}
+
+// SliceSource is a WarmSource sending the entries of the slice in order, such
+// as those returned by Entries or Snapshot.Entries.
+type SliceSource[K comparable, V any] []Entry[K, V]
+
+func (s SliceSource[K, V]) Entries(ctx context.Context) (<-chan Entry[K, V], error) {
+	entries := make(chan Entry[K, V])
+	go func() {
+		defer close(entries)
+		for _, entry := range s {
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return entries, nil
+}
+
+// Warm preloads the cache with the entries of source, so the first entry ends
+// up as the most recently used one. Entries are stored like values filled by
+// a loader: they are not written through and use the default lifetime set
+// with WithTTL. They go through the eviction policy as usual, but only as many
+// entries as the cache can hold are read, since later ones would be evicted
+// right away. When ctx is done before source closes its channel, the cache is
+// left unchanged and the error of ctx is returned.
+func (c *Cache[K, V]) Warm(ctx context.Context, source WarmSource[K, V]) error {
+	entries, err := readWarmSource(ctx, source, c.Cap())
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.warm(entries)
+	return nil
+}
+
+// warm stores entries, given from the most to the least important one.
+func (c *Cache[K, V]) warm(entries []Entry[K, V]) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		c.store(entries[i].Key, entries[i].Value)
+	}
+}
+
+// readWarmSource collects the first limit entries of source. The remaining
+// ones are received and dropped, so source is not left blocked.
+func readWarmSource[K comparable, V any](ctx context.Context, source WarmSource[K, V], limit int) ([]Entry[K, V], error) {
+	ch, err := source.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry[K, V]
+	for {
+		select {
+		case entry, ok := <-ch:
+			if !ok {
+				return entries, nil
+			}
+			if len(entries) < limit {
+				entries = append(entries, entry)
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Warm behaves like Cache.Warm.
+func (s *SyncCache[K, V]) Warm(ctx context.Context, source WarmSource[K, V]) error {
+	return s.cache.Warm(ctx, source)
+}
+
+// Warm behaves like Cache.Warm, with each entry stored in its shard. Up to
+// the combined capacity of the shards is read, so a shard receiving more
+// entries than it holds evicts the least important ones.
+func (c *ShardedCache[K, V]) Warm(ctx context.Context, source WarmSource[K, V]) error {
+	entries, err := readWarmSource(ctx, source, c.Cap())
+	if err != nil {
+		return err
+	}
+
+	byShard := make([][]Entry[K, V], len(c.shards))
+	for _, entry := range entries {
+		i := hashKey(entry.Key) & c.mask
+		byShard[i] = append(byShard[i], entry)
+	}
+	for i, s := range c.shards {
+		if len(byShard[i]) == 0 {
+			continue
+		}
+		s.cache.mu.Lock()
+		s.cache.warm(byShard[i])
+		s.cache.mu.Unlock()
+	}
+	return nil
+}
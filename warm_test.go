@@ -0,0 +1,73 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWarm(t *testing.T) {
+	written := 0
+	cache := New(3, WithWriteThrough(func(key string, value int) error {
+		written++
+		return nil
+	}))
+	cache.Set("Tee", 0)
+
+	source := SliceSource[string, int]{{"Dog", 1}, {"Cat", 2}, {"Soda", 3}, {"Milk", 4}}
+	if err := cache.Warm(context.Background(), source); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCacheState := []string{"Dog", "Cat", "Soda"}
+	actualCacheState := getCacheState(cache)
+	if !equalSlice(expectedCacheState, actualCacheState) {
+		t.Errorf("Expected cache state: %v, but got: %v", expectedCacheState, actualCacheState)
+	}
+	if written != 1 {
+		t.Errorf("Expected warmed entries not to be written through, but got %d writes", written)
+	}
+
+	sharded, _ := NewSharded[string, int](8, 2)
+	if err := sharded.Warm(context.Background(), source); err != nil {
+		t.Fatal(err)
+	}
+	if sharded.Len() != 4 {
+		t.Errorf("Expected 4 warmed entries in the sharded cache, but got: %d", sharded.Len())
+	}
+}
+
+// blockingSource sends its entries and then blocks without closing the
+// channel.
+type blockingSource struct{}
+
+func (blockingSource) Entries(ctx context.Context) (<-chan Entry[string, int], error) {
+	ch := make(chan Entry[string, int], 1)
+	ch <- Entry[string, int]{"Dog", 1}
+	return ch, nil
+}
+
+// failingSource cannot be opened.
+type failingSource struct{}
+
+var errSourceUnavailable = errors.New("source unavailable")
+
+func (failingSource) Entries(ctx context.Context) (<-chan Entry[string, int], error) {
+	return nil, errSourceUnavailable
+}
+
+func TestWarmErrors(t *testing.T) {
+	cache := NewSync[string, int](3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := cache.Warm(ctx, blockingSource{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got: %v", err)
+	}
+	if err := cache.Warm(context.Background(), failingSource{}); !errors.Is(err, errSourceUnavailable) {
+		t.Errorf("Expected the source error, but got: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected a failed warm to leave the cache unchanged, but got Len %d", cache.Len())
+	}
+}
@@ -0,0 +1,80 @@
+package lru
+
+import "context"
+
+// WatchKey returns a channel receiving the new value whenever key is set, and
+// the zero value whenever it is deleted, cleared, evicted or expires. The
+// channel holds one value: a value which is not received before the next
+// change is replaced by it, so a slow watcher only sees the latest one. The
+// channel is closed once ctx is done. Unlike the other methods, WatchKey may be
+// called concurrently with the cache being used.
+func (c *Cache[K, V]) WatchKey(ctx context.Context, key K) <-chan V {
+	ch := make(chan V, 1)
+	if ctx.Err() != nil {
+		close(ch)
+		return ch
+	}
+
+	c.watchMu.Lock()
+	if c.watchers == nil {
+		c.watchers = map[K][]chan V{}
+	}
+	c.watchers[key] = append(c.watchers[key], ch)
+	c.watching.Add(1)
+	c.watchMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.unwatch(key, ch)
+	}()
+	return ch
+}
+
+func (c *Cache[K, V]) unwatch(key K, ch chan V) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	watchers := c.watchers[key]
+	for i, w := range watchers {
+		if w == ch {
+			watchers = append(watchers[:i], watchers[i+1:]...)
+			break
+		}
+	}
+	if len(watchers) == 0 {
+		delete(c.watchers, key)
+	} else {
+		c.watchers[key] = watchers
+	}
+	c.watching.Add(-1)
+	close(ch)
+}
+
+// notifyWatchers sends value to the channels returned by WatchKey for key,
+// replacing a value which was not received yet.
+func (c *Cache[K, V]) notifyWatchers(key K, value V) {
+	if c.watching.Load() == 0 {
+		return
+	}
+
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	for _, ch := range c.watchers[key] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- value
+	}
+}
+
+// WatchKey behaves like Cache.WatchKey.
+func (s *SyncCache[K, V]) WatchKey(ctx context.Context, key K) <-chan V {
+	return s.cache.WatchKey(ctx, key)
+}
+
+// WatchKey behaves like Cache.WatchKey.
+func (c *ShardedCache[K, V]) WatchKey(ctx context.Context, key K) <-chan V {
+	return c.shard(key).WatchKey(ctx, key)
+}
@@ -0,0 +1,70 @@
+package lru
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func receiveWatch[V any](t *testing.T, ch <-chan V) V {
+	t.Helper()
+
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(time.Second):
+		t.Fatal("Expected a value, but got none")
+		var zero V
+		return zero
+	}
+}
+
+func TestWatchKey(t *testing.T) {
+	cache := New[string, string](2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dog := cache.WatchKey(ctx, "Dog")
+
+	cache.Set("Dog", "Woof")
+	if value := receiveWatch(t, dog); value != "Woof" {
+		t.Errorf("Expected Woof, but got: %q", value)
+	}
+
+	cache.Set("Cat", "Meow")
+	cache.Delete("Cat")
+	select {
+	case value := <-dog:
+		t.Errorf("Expected no value for changes to other keys, but got: %q", value)
+	default:
+	}
+
+	// Only the latest value is kept for a slow watcher.
+	cache.Set("Dog", "Bark")
+	cache.Set("Dog", "Growl")
+	if value := receiveWatch(t, dog); value != "Growl" {
+		t.Errorf("Expected Growl, but got: %q", value)
+	}
+
+	cache.Set("Cat", "Meow")
+	cache.Set("Soda", "Fizz")
+	if value := receiveWatch(t, dog); value != "" {
+		t.Errorf("Expected an empty value for an evicted key, but got: %q", value)
+	}
+
+	cancel()
+	if _, ok := <-dog; ok {
+		t.Error("Expected the channel to be closed once the context is done")
+	}
+}
+
+func TestWatchKeyDoneContext(t *testing.T) {
+	cache := NewSync[string, string](2)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, ok := <-cache.WatchKey(ctx, "Dog"); ok {
+		t.Error("Expected a closed channel for a done context")
+	}
+	cache.Set("Dog", "Woof")
+}
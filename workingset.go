@@ -0,0 +1,90 @@
+package lru
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Hubert-Madej/go-lru-cache/sketch"
+)
+
+// workingSetBuckets is the number of time buckets a WorkingSetEstimator
+// splits its window into, and workingSetPrecision the precision of their
+// HyperLogLog sketches, giving a standard error of about 1.6% in 64 KiB.
+const (
+	workingSetBuckets   = 16
+	workingSetPrecision = 12
+)
+
+// WorkingSetEstimator estimates how many distinct keys were accessed within a
+// sliding time window, the working set. A cache at least that large would
+// only miss on keys accessed for the first time in the window, so it is a
+// guide for sizing the cache.
+//
+// The window is split into 16 buckets, each holding a HyperLogLog sketch of
+// the keys accessed during it, so memory does not grow with the number of
+// keys. As buckets expire as a whole, the estimate covers between 15/16 of
+// the window and the whole window. A WorkingSetEstimator is safe for
+// concurrent use.
+type WorkingSetEstimator[K comparable] struct {
+	mu         sync.Mutex
+	bucketSize time.Duration
+	buckets    [workingSetBuckets]*sketch.HyperLogLog
+	// slots holds the number of the bucketSize period each bucket counts,
+	// counted from the Unix epoch.
+	slots [workingSetBuckets]int64
+	now   func() time.Time
+}
+
+// NewWorkingSetEstimator creates an estimator for the keys accessed within
+// the last windowSize, which is raised to 16ns if it is smaller.
+func NewWorkingSetEstimator[K comparable](windowSize time.Duration) *WorkingSetEstimator[K] {
+	e := &WorkingSetEstimator[K]{
+		bucketSize: max(windowSize/workingSetBuckets, 1),
+		now:        time.Now,
+	}
+	for i := range e.buckets {
+		e.buckets[i] = sketch.NewHyperLogLog(workingSetPrecision)
+		e.slots[i] = -1
+	}
+	return e
+}
+
+// WithWorkingSetEstimator makes the cache record the keys looked up by Get,
+// Fetch and the other reads in e, hits and misses alike. e may be shared by
+// several caches, such as the shards of a ShardedCache, to estimate their
+// combined working set.
+func WithWorkingSetEstimator[K comparable, V any](e *WorkingSetEstimator[K]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.workingSet = e
+	}
+}
+
+// Observe records an access to key.
+func (e *WorkingSetEstimator[K]) Observe(key K) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	slot := e.now().UnixNano() / int64(e.bucketSize)
+	i := slot % workingSetBuckets
+	if e.slots[i] != slot {
+		e.buckets[i].Reset()
+		e.slots[i] = slot
+	}
+	e.buckets[i].AddHash(hashKey(key))
+}
+
+// UniqueKeysInWindow returns the estimated number of distinct keys observed
+// within the window.
+func (e *WorkingSetEstimator[K]) UniqueKeysInWindow() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	current := e.now().UnixNano() / int64(e.bucketSize)
+	merged := sketch.NewHyperLogLog(workingSetPrecision)
+	for i, slot := range e.slots {
+		if slot > current-workingSetBuckets && slot <= current {
+			merged.Merge(e.buckets[i])
+		}
+	}
+	return int(merged.Count())
+}
@@ -0,0 +1,55 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkingSetEstimator(t *testing.T) {
+	e := NewWorkingSetEstimator[int](time.Minute)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	e.now = clock.Now
+
+	if n := e.UniqueKeysInWindow(); n != 0 {
+		t.Errorf("Expected an empty working set, but got: %d", n)
+	}
+
+	for i := 0; i < 1000; i++ {
+		e.Observe(i)
+		e.Observe(i)
+	}
+	clock.Advance(30 * time.Second)
+	for i := 500; i < 1500; i++ {
+		e.Observe(i)
+	}
+	if n := e.UniqueKeysInWindow(); n < 1425 || n > 1575 {
+		t.Errorf("Expected about 1500 keys in the window, but got: %d", n)
+	}
+
+	// The first thousand keys leave the window, the keys seen 30s later
+	// stay.
+	clock.Advance(45 * time.Second)
+	if n := e.UniqueKeysInWindow(); n < 950 || n > 1050 {
+		t.Errorf("Expected about 1000 keys in the window, but got: %d", n)
+	}
+
+	clock.Advance(time.Minute)
+	if n := e.UniqueKeysInWindow(); n != 0 {
+		t.Errorf("Expected the window to be empty, but got: %d", n)
+	}
+}
+
+func TestWithWorkingSetEstimator(t *testing.T) {
+	e := NewWorkingSetEstimator[string](time.Minute)
+	cache, err := NewSharded(4, 2, WithWorkingSetEstimator[string, int](e))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"Dog", "Cat", "Soda", "Dog", "Tee"} {
+		cache.Get(key)
+	}
+	if n := e.UniqueKeysInWindow(); n != 4 {
+		t.Errorf("Expected 4 keys in the working set, but got: %d", n)
+	}
+}
@@ -0,0 +1,83 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithWriteThrough(t *testing.T) {
+	store := map[string]int{}
+	errStoreDown := errors.New("store is down")
+	down := false
+
+	cache := New(3, WithWriteThrough(func(key string, value int) error {
+		if down {
+			return errStoreDown
+		}
+		store[key] = value
+		return nil
+	}))
+
+	if err := cache.Set("Dog", 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := cache.SetWithTTL("Cat", 2, time.Minute); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cache.SetNX("Soda", 3)
+	cache.Swap("Dog", 10)
+
+	expectedStore := map[string]int{"Dog": 10, "Cat": 2, "Soda": 3}
+	for key, value := range expectedStore {
+		if store[key] != value {
+			t.Errorf("Expected store to hold %s=%d, but got: %d", key, value, store[key])
+		}
+	}
+
+	down = true
+	if err := cache.Set("Dog", 20); !errors.Is(err, errStoreDown) {
+		t.Errorf("Expected store error, but got: %v", err)
+	}
+	if err := cache.Set("Tee", 4); !errors.Is(err, errStoreDown) {
+		t.Errorf("Expected store error, but got: %v", err)
+	}
+	if cache.SetNX("Terry", 5) {
+		t.Errorf("Expected SetNX to fail when the store is down")
+	}
+	if old, ok := cache.Swap("Cat", 30); ok || old != 0 {
+		t.Errorf("Expected (0, false) from failed Swap, but got: (%d, %t)", old, ok)
+	}
+
+	if value, _ := cache.Peek("Dog"); value != 10 {
+		t.Errorf("Expected failed Set to keep Dog at 10, but got: %d", value)
+	}
+	if value, _ := cache.Peek("Cat"); value != 2 {
+		t.Errorf("Expected failed Swap to keep Cat at 2, but got: %d", value)
+	}
+	if cache.Contains("Tee") || cache.Contains("Terry") {
+		t.Errorf("Expected failed writes not to be cached")
+	}
+}
+
+func TestWriteThroughSkipsLoadedValues(t *testing.T) {
+	writes := 0
+	cache := New(2,
+		WithLoader(func(key string) (int, error) {
+			return len(key), nil
+		}),
+		WithWriteThrough(func(key string, value int) error {
+			writes++
+			return nil
+		}),
+	)
+
+	cache.Get("Dog")
+	cache.GetOrSet("Cat", func() (int, error) {
+		return 2, nil
+	})
+
+	if writes != 0 {
+		t.Errorf("Expected loaded values not to be written back, but got %d writes", writes)
+	}
+}